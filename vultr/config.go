@@ -3,6 +3,10 @@ package vultr
 import (
 	"context"
 	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
@@ -11,23 +15,74 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// apiRequestInterval keeps the provider under the Vultr API limit of 30
+// requests per second.
+const apiRequestInterval = time.Second / 30
+
 // Config is the configuration structure used to instantiate Vultr
 type Config struct {
 	APIKey     string
 	RateLimit  int
 	RetryLimit int
+	APIURL     string
+	Debug      bool
+	DefaultTag string
 }
 
-// Client wraps govultr
+// Client wraps govultr. The govultr services are interfaces, so tests can
+// replace them with fakes, see newFakeClient, or point the whole client at a
+// mock API, see newTestClient.
 type Client struct {
-	client *govultr.Client
+	client     *govultr.Client
+	defaultTag string
 }
 
+// govultrClient returns the govultr client built once by Config.Client when
+// the provider is configured. Every resource shares it, and with it the rate
+// limit transport and the connection pool, so a parallel apply stays within
+// the API rate limit. govultr clients are safe for concurrent use.
 func (c *Client) govultrClient() *govultr.Client {
 	return c.client
 }
 
-// Client configures govultr and returns an initialized client
+// tagOrDefault returns tag, or the provider default_tag when tag is empty.
+func (c *Client) tagOrDefault(tag string) string {
+	if tag == "" {
+		return c.defaultTag
+	}
+	return tag
+}
+
+// stateTag returns the tag to keep in state. A resource that does not set a
+// tag keeps it empty when the remote tag is the provider default_tag, so the
+// default does not show up as a diff on every plan.
+func (c *Client) stateTag(configured, remote string) string {
+	if configured == "" && remote == c.defaultTag {
+		return ""
+	}
+	return remote
+}
+
+// tagsOrDefault returns tags, or the provider default_tag as the only tag
+// when tags is empty.
+func (c *Client) tagsOrDefault(tags []string) []string {
+	if len(tags) == 0 && c.defaultTag != "" {
+		return []string{c.defaultTag}
+	}
+	return tags
+}
+
+// stateTags is stateTag for the tags list. A resource that does not set tags
+// keeps them empty when the only remote tag is the provider default_tag.
+func (c *Client) stateTags(configured, remote []string) []string {
+	if len(configured) == 0 && len(remote) == 1 && remote[0] == c.defaultTag {
+		return nil
+	}
+	return remote
+}
+
+// Client configures govultr and returns an initialized client. It is called
+// once per provider configuration, the result is the provider meta.
 func (c *Config) Client() (*Client, error) {
 	userAgent := fmt.Sprintf("Terraform/%s", meta.SDKVersionString())
 	tokenSrc := oauth2.StaticTokenSource(&oauth2.Token{
@@ -35,7 +90,11 @@ func (c *Config) Client() (*Client, error) {
 	})
 
 	client := oauth2.NewClient(context.Background(), tokenSrc)
+	client.Transport = newRateLimitTransport(client.Transport, apiRequestInterval)
 	client.Transport = logging.NewTransport("Vultr", client.Transport)
+	if c.Debug {
+		client.Transport = newDebugTransport(client.Transport, c.APIKey)
+	}
 
 	vultrClient := govultr.NewClient(client)
 	vultrClient.SetUserAgent(userAgent)
@@ -44,9 +103,93 @@ func (c *Config) Client() (*Client, error) {
 		vultrClient.SetRateLimit(time.Duration(c.RateLimit) * time.Millisecond)
 	}
 
+	// govultr retries 429 and 5xx responses with exponential backoff and
+	// honours the Retry-After header returned by the API.
 	if c.RetryLimit != 0 {
 		vultrClient.SetRetryLimit(c.RetryLimit)
 	}
 
-	return &Client{client: vultrClient}, nil
+	if c.APIURL != "" {
+		if err := vultrClient.SetBaseURL(c.APIURL); err != nil {
+			return nil, fmt.Errorf("invalid api_url %q: %v", c.APIURL, err)
+		}
+	}
+
+	return &Client{client: vultrClient, defaultTag: c.DefaultTag}, nil
+}
+
+// rateLimitTransport spaces outgoing requests at least interval apart so
+// parallel resource operations stay within the Vultr API rate limit.
+type rateLimitTransport struct {
+	base     http.RoundTripper
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+func newRateLimitTransport(base http.RoundTripper, interval time.Duration) *rateLimitTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitTransport{base: base, interval: interval}
+}
+
+// RoundTrip waits for the next free slot before sending the request.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	now := time.Now()
+	wait := t.next.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	t.next = now.Add(wait + t.interval)
+	t.mu.Unlock()
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// debugTransport logs the method, URL and status of every API call. The API
+// key is sent in the Authorization header, which is never logged, and is
+// redacted should it show up anywhere in the logged line.
+type debugTransport struct {
+	base   http.RoundTripper
+	apiKey string
+}
+
+func newDebugTransport(base http.RoundTripper, apiKey string) *debugTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &debugTransport{base: base, apiKey: apiKey}
+}
+
+// RoundTrip sends the request and logs its outcome.
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		t.logf("[INFO] Vultr API %s %s failed: %v", req.Method, req.URL, err)
+		return resp, err
+	}
+
+	t.logf("[INFO] Vultr API %s %s: %s", req.Method, req.URL, resp.Status)
+	return resp, nil
+}
+
+func (t *debugTransport) logf(format string, v ...interface{}) {
+	line := fmt.Sprintf(format, v...)
+	if t.apiKey != "" {
+		line = strings.ReplaceAll(line, t.apiKey, "<redacted>")
+	}
+	log.Print(line)
 }
@@ -0,0 +1,165 @@
+package vultr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	interval := 50 * time.Millisecond
+	client := &http.Client{Transport: newRateLimitTransport(nil, interval)}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < 2*interval {
+		t.Fatalf("expected requests to be spaced %s apart, 3 requests took %s", interval, elapsed)
+	}
+}
+
+func TestClientSharedRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"account":{"name":"mock"}}`)
+	}))
+	defer server.Close()
+
+	config := Config{APIKey: "test", APIURL: server.URL}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client.govultrClient() != client.govultrClient() {
+		t.Fatal("expected every call to return the same govultr client")
+	}
+
+	// Requests from parallel resources are spaced by the one rate limiter
+	const requests = 6
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.govultrClient().Account.Get(context.Background()); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < (requests-1)*apiRequestInterval {
+		t.Fatalf("expected %d parallel requests to be spaced %s apart, they took %s", requests, apiRequestInterval, elapsed)
+	}
+}
+
+func TestConfigClientAPIURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/account" {
+			t.Errorf("unexpected request path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"account":{"name":"mock"}}`)
+	}))
+	defer server.Close()
+
+	config := Config{APIKey: "test", APIURL: server.URL}
+	client, err := config.Client()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	account, err := client.govultrClient().Account.Get(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if account.Name != "mock" {
+		t.Fatalf("expected account from mock server, got %q", account.Name)
+	}
+}
+
+func TestClientDefaultTag(t *testing.T) {
+	client, err := (&Config{APIKey: "test", DefaultTag: "team-a"}).Client()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := client.tagOrDefault(""); got != "team-a" {
+		t.Errorf("expected the default tag for an unset tag, got %q", got)
+	}
+	if got := client.tagOrDefault("web"); got != "web" {
+		t.Errorf("expected the resource tag to override the default, got %q", got)
+	}
+	if got := client.stateTag("", "team-a"); got != "" {
+		t.Errorf("expected the default tag to be hidden from state, got %q", got)
+	}
+	if got := client.stateTag("", "web"); got != "web" {
+		t.Errorf("expected a tag set outside terraform to be kept, got %q", got)
+	}
+	if got := client.stateTag("team-a", "team-a"); got != "team-a" {
+		t.Errorf("expected a configured tag to be kept, got %q", got)
+	}
+
+	if got := client.tagsOrDefault(nil); !reflect.DeepEqual(got, []string{"team-a"}) {
+		t.Errorf("expected the default tag for unset tags, got %v", got)
+	}
+	if got := client.tagsOrDefault([]string{"web"}); !reflect.DeepEqual(got, []string{"web"}) {
+		t.Errorf("expected the resource tags to override the default, got %v", got)
+	}
+	if got := client.stateTags(nil, []string{"team-a"}); len(got) != 0 {
+		t.Errorf("expected the default tag to be hidden from state, got %v", got)
+	}
+	if got := client.stateTags(nil, []string{"team-a", "web"}); len(got) != 2 {
+		t.Errorf("expected tags set outside terraform to be kept, got %v", got)
+	}
+	if got := client.stateTags([]string{"team-a"}, []string{"team-a"}); len(got) != 1 {
+		t.Errorf("expected configured tags to be kept, got %v", got)
+	}
+}
+
+func TestDebugTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	client := &http.Client{Transport: newDebugTransport(nil, "secret-key")}
+	resp, err := client.Get(server.URL + "/v2/instances?token=secret-key")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	out := buf.String()
+	if !strings.Contains(out, "GET "+server.URL+"/v2/instances") || !strings.Contains(out, "404 Not Found") {
+		t.Errorf("expected the method, URL and status to be logged, got %q", out)
+	}
+	if strings.Contains(out, "secret-key") {
+		t.Errorf("expected the API key to be redacted, got %q", out)
+	}
+}
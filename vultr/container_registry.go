@@ -0,0 +1,117 @@
+package vultr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/vultr/govultr/v2"
+)
+
+// govultr has no container registry service, so the registry endpoints are
+// called directly through the govultr client.
+const registryPath = "/v2/registry"
+
+type containerRegistry struct {
+	ID          string                    `json:"id"`
+	Name        string                    `json:"name"`
+	URN         string                    `json:"urn"`
+	Storage     containerRegistryStorage  `json:"storage"`
+	DateCreated string                    `json:"date_created"`
+	Public      bool                      `json:"public"`
+	Metadata    containerRegistryMetadata `json:"metadata"`
+}
+
+type containerRegistryStorage struct {
+	Used    containerRegistrySize `json:"used"`
+	Allowed containerRegistrySize `json:"allowed"`
+}
+
+type containerRegistrySize struct {
+	GB float64 `json:"gb"`
+}
+
+type containerRegistryMetadata struct {
+	Region struct {
+		Name string `json:"name"`
+	} `json:"region"`
+}
+
+type containerRegistryReq struct {
+	Name   string `json:"name,omitempty"`
+	Region string `json:"region,omitempty"`
+	Plan   string `json:"plan,omitempty"`
+	Public *bool  `json:"public,omitempty"`
+}
+
+type containerRegistryBase struct {
+	Registry *containerRegistry `json:"registry"`
+}
+
+func createContainerRegistry(ctx context.Context, client *govultr.Client, registryReq *containerRegistryReq) (*containerRegistry, error) {
+	req, err := client.NewRequest(ctx, http.MethodPost, registryPath, registryReq)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := new(containerRegistryBase)
+	if err := client.DoWithContext(ctx, req, registry); err != nil {
+		return nil, err
+	}
+
+	return registry.Registry, nil
+}
+
+func getContainerRegistry(ctx context.Context, client *govultr.Client, registryID string) (*containerRegistry, error) {
+	req, err := client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s", registryPath, registryID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := new(containerRegistryBase)
+	if err := client.DoWithContext(ctx, req, registry); err != nil {
+		return nil, err
+	}
+
+	return registry.Registry, nil
+}
+
+func updateContainerRegistry(ctx context.Context, client *govultr.Client, registryID string, registryReq *containerRegistryReq) error {
+	req, err := client.NewRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%s", registryPath, registryID), registryReq)
+	if err != nil {
+		return err
+	}
+
+	return client.DoWithContext(ctx, req, nil)
+}
+
+func deleteContainerRegistry(ctx context.Context, client *govultr.Client, registryID string) error {
+	req, err := client.NewRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%s", registryPath, registryID), nil)
+	if err != nil {
+		return err
+	}
+
+	return client.DoWithContext(ctx, req, nil)
+}
+
+// getContainerRegistryDockerCredentials returns a docker config.json with
+// non-expiring read only credentials for the registry.
+func getContainerRegistryDockerCredentials(ctx context.Context, client *govultr.Client, registryID string) (string, error) {
+	req, err := client.NewRequest(ctx, http.MethodOptions, fmt.Sprintf("%s/%s/docker-credentials", registryPath, registryID), nil)
+	if err != nil {
+		return "", err
+	}
+
+	q := req.URL.Query()
+	q.Set("expiry_seconds", "0")
+	q.Set("read_write", "false")
+	req.URL.RawQuery = q.Encode()
+
+	var credentials json.RawMessage
+	if err := client.DoWithContext(ctx, req, &credentials); err != nil {
+		return "", err
+	}
+
+	return string(credentials), nil
+}
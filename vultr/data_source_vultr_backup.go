@@ -2,9 +2,13 @@ package vultr
 
 import (
 	"context"
+	"net/http"
+	"regexp"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vultr/govultr/v2"
 )
 
@@ -13,11 +17,37 @@ func dataSourceVultrBackup() *schema.Resource {
 		ReadContext: dataSourceVultrBackupRead,
 		Schema: map[string]*schema.Schema{
 			"filter": dataSourceFiltersSchema(),
+			"instance_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
 			"backups": {
 				Type:     schema.TypeList,
 				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeMap},
 			},
+			// Attributes of the most recent matching backup
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"date_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -25,18 +55,22 @@ func dataSourceVultrBackup() *schema.Resource {
 func dataSourceVultrBackupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
-	filters, filtersOk := d.GetOk("filter")
+	var f []filter
+	if filters, filtersOk := d.GetOk("filter"); filtersOk {
+		f = buildVultrDataSourceFilter(filters.(*schema.Set))
+	}
 
-	if !filtersOk {
-		return diag.Errorf("issue with filter: %v", filtersOk)
+	var descriptionRegex *regexp.Regexp
+	if v, ok := d.GetOk("description_regex"); ok {
+		descriptionRegex = regexp.MustCompile(v.(string))
 	}
 
+	var matches []govultr.Backup
 	var backupList []map[string]interface{}
-	f := buildVultrDataSourceFilter(filters.(*schema.Set))
 	options := &govultr.ListOptions{}
 
 	for {
-		backups, meta, err := client.Backup.List(ctx, options)
+		backups, meta, err := listBackups(ctx, client, d.Get("instance_id").(string), options)
 		if err != nil {
 			return diag.Errorf("error getting backups: %v", err)
 		}
@@ -48,7 +82,12 @@ func dataSourceVultrBackupRead(ctx context.Context, d *schema.ResourceData, meta
 				return diag.FromErr(err)
 			}
 
+			if descriptionRegex != nil && !descriptionRegex.MatchString(b.Description) {
+				continue
+			}
+
 			if filterLoop(f, sm) {
+				matches = append(matches, b)
 				backupList = append(backupList, sm)
 			}
 		}
@@ -65,10 +104,55 @@ func dataSourceVultrBackupRead(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("no results were found")
 	}
 
-	d.SetId(backupList[0]["description"].(string))
+	latest := latestBackup(matches)
+
+	d.SetId(latest.ID)
+	d.Set("description", latest.Description)
+	d.Set("date_created", latest.DateCreated)
+	d.Set("status", latest.Status)
+	d.Set("size", latest.Size)
 	if err := d.Set("backups", backupList); err != nil {
 		return diag.Errorf("error setting `backups`: %#v", err)
 	}
 
 	return nil
 }
+
+// listBackups lists backups, optionally limited to a single instance. govultr
+// has no instance_id list option so the query parameter is added here.
+func listBackups(ctx context.Context, client *govultr.Client, instanceID string, options *govultr.ListOptions) ([]govultr.Backup, *govultr.Meta, error) {
+	req, err := client.NewRequest(ctx, http.MethodGet, "/v2/backups", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	q := req.URL.Query()
+	if instanceID != "" {
+		q.Set("instance_id", instanceID)
+	}
+	if options.Cursor != "" {
+		q.Set("cursor", options.Cursor)
+	}
+	req.URL.RawQuery = q.Encode()
+
+	var backups struct {
+		Backups []govultr.Backup `json:"backups"`
+		Meta    *govultr.Meta    `json:"meta"`
+	}
+	if err := client.DoWithContext(ctx, req, &backups); err != nil {
+		return nil, nil, err
+	}
+
+	return backups.Backups, backups.Meta, nil
+}
+
+// latestBackup returns the most recently created backup. Backups with a
+// date_created that can't be parsed are treated as the oldest.
+func latestBackup(backups []govultr.Backup) govultr.Backup {
+	sorted := append([]govultr.Backup(nil), backups...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return createdAfter(sorted[i].DateCreated, sorted[j].DateCreated)
+	})
+
+	return sorted[0]
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vultr/govultr/v2"
 )
 
 func TestAccVultrBackup(t *testing.T) {
@@ -23,12 +24,48 @@ func TestAccVultrBackup(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.vultr_backup.backs", "backups.0.size"),
 					resource.TestCheckResourceAttrSet("data.vultr_backup.backs", "backups.0.date_created"),
 					resource.TestCheckResourceAttr("data.vultr_backup.backs", "backups.0.status", "complete"),
+					resource.TestCheckResourceAttr("data.vultr_backup.backs", "status", "complete"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccVultrBackupDescriptionRegex(t *testing.T) {
+	if os.Getenv("CI") == "" {
+		t.Skip("Skipping testing in Non-CI environment")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVultrBackupRegex("TF-BACKUPS-DND$"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vultr_backup.latest", "id"),
+					resource.TestCheckResourceAttrSet("data.vultr_backup.latest", "date_created"),
+					resource.TestCheckResourceAttrSet("data.vultr_backup.latest", "size"),
+					resource.TestCheckResourceAttr("data.vultr_backup.latest", "status", "complete"),
+				),
+			},
+		},
+	})
+}
+
+func TestLatestBackup(t *testing.T) {
+	backups := []govultr.Backup{
+		{ID: "old", DateCreated: "2022-08-01T02:00:00+00:00"},
+		{ID: "invalid", DateCreated: ""},
+		{ID: "new", DateCreated: "2022-08-03T02:00:00+00:00"},
+		{ID: "middle", DateCreated: "2022-08-02T02:00:00+00:00"},
+	}
+
+	if latest := latestBackup(backups); latest.ID != "new" {
+		t.Fatalf("expected latest backup to be new, got %s", latest.ID)
+	}
+}
+
 func testAccVultrBackupRead(description string) string {
 	return fmt.Sprintf(`
 		data "vultr_backup" "backs" {
@@ -38,3 +75,10 @@ func testAccVultrBackupRead(description string) string {
 			}
 		}`, description)
 }
+
+func testAccVultrBackupRegex(regex string) string {
+	return fmt.Sprintf(`
+		data "vultr_backup" "latest" {
+			description_regex = "%s"
+		}`, regex)
+}
@@ -2,6 +2,7 @@ package vultr
 
 import (
 	"context"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -13,6 +14,11 @@ func dataSourceVultrBareMetalPlan() *schema.Resource {
 		ReadContext: dataSourceVultrBareMetalPlanRead,
 		Schema: map[string]*schema.Schema{
 			"filter": dataSourceFiltersSchema(),
+			"cheapest": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"cpu_count": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -38,7 +44,7 @@ func dataSourceVultrBareMetalPlan() *schema.Resource {
 				Computed: true,
 			},
 			"monthly_cost": {
-				Type:     schema.TypeInt,
+				Type:     schema.TypeFloat,
 				Computed: true,
 			},
 			"type": {
@@ -99,14 +105,20 @@ func dataSourceVultrBareMetalPlanRead(ctx context.Context, d *schema.ResourceDat
 			continue
 		}
 	}
-	if len(planList) > 1 {
-		return diag.Errorf("your search returned too many results. Please refine your search to be more specific")
-	}
 
 	if len(planList) < 1 {
 		return diag.Errorf("no results were found")
 	}
 
+	if d.Get("cheapest").(bool) {
+		sortBareMetalPlansByCost(planList)
+		planList = planList[:1]
+	}
+
+	if len(planList) > 1 {
+		return diag.Errorf("your search returned too many results. Please refine your search to be more specific")
+	}
+
 	d.SetId(planList[0].ID)
 	d.Set("cpu_count", planList[0].CPUCount)
 	d.Set("cpu_model", planList[0].CPUModel)
@@ -124,3 +136,11 @@ func dataSourceVultrBareMetalPlanRead(ctx context.Context, d *schema.ResourceDat
 
 	return nil
 }
+
+// sortBareMetalPlansByCost orders plans from the lowest to the highest monthly
+// cost, keeping the API order for plans with the same price.
+func sortBareMetalPlansByCost(plans []govultr.BareMetalPlan) {
+	sort.SliceStable(plans, func(i, j int) bool {
+		return plans[i].MonthlyCost < plans[j].MonthlyCost
+	})
+}
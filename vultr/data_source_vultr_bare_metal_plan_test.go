@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vultr/govultr/v2"
 )
 
 func TestAccVultrBareMetalPlan(t *testing.T) {
@@ -31,6 +32,36 @@ func TestAccVultrBareMetalPlan(t *testing.T) {
 	})
 }
 
+func TestAccVultrBareMetalPlanCheapest(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVultrBareMetalPlanCheapest(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vultr_bare_metal_plan.cheapest", "id"),
+					resource.TestCheckResourceAttrSet("data.vultr_bare_metal_plan.cheapest", "monthly_cost"),
+				),
+			},
+		},
+	})
+}
+
+func TestSortBareMetalPlansByCost(t *testing.T) {
+	plans := []govultr.BareMetalPlan{
+		{ID: "vbm-8c-132gb", MonthlyCost: 350},
+		{ID: "vbm-4c-32gb", MonthlyCost: 120},
+		{ID: "vbm-6c-32gb", MonthlyCost: 185},
+	}
+
+	sortBareMetalPlansByCost(plans)
+
+	if plans[0].ID != "vbm-4c-32gb" || plans[2].ID != "vbm-8c-132gb" {
+		t.Fatalf("unexpected plan order: %v", plans)
+	}
+}
+
 func testAccCheckVultrBareMetalPlan(name string) string {
 	return fmt.Sprintf(`
 		data "vultr_bare_metal_plan" "my_bm_plan" {
@@ -40,3 +71,15 @@ func testAccCheckVultrBareMetalPlan(name string) string {
 			}
 		}`, name)
 }
+
+func testAccCheckVultrBareMetalPlanCheapest() string {
+	return `
+		data "vultr_bare_metal_plan" "cheapest" {
+			cheapest = true
+
+			filter {
+				name   = "locations"
+				values = ["ewr"]
+			}
+		}`
+}
@@ -0,0 +1,122 @@
+package vultr
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vultr/govultr/v2"
+)
+
+func dataSourceVultrBareMetalPlans() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceVultrBareMetalPlansRead,
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"plans": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cpu_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"cpu_model": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cpu_threads": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"ram": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"disk": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"disk_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"bandwidth": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"monthly_cost": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"locations": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceVultrBareMetalPlansRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).govultrClient()
+
+	var plans []govultr.BareMetalPlan
+	err := listAll(func(options *govultr.ListOptions) (*govultr.Meta, error) {
+		page, meta, err := client.Plan.ListBareMetal(ctx, options)
+		plans = append(plans, page...)
+		return meta, err
+	})
+	if err != nil {
+		return diag.Errorf("error getting bare metal plans: %v", err)
+	}
+
+	region, regionOk := d.GetOk("region")
+
+	planList := make([]interface{}, 0)
+	for _, plan := range plans {
+		if regionOk && !planHasLocation(plan.Locations, region.(string)) {
+			continue
+		}
+
+		planList = append(planList, map[string]interface{}{
+			"id":           plan.ID,
+			"cpu_count":    plan.CPUCount,
+			"cpu_model":    plan.CPUModel,
+			"cpu_threads":  plan.CPUThreads,
+			"ram":          plan.RAM,
+			"disk":         plan.Disk,
+			"disk_count":   plan.DiskCount,
+			"bandwidth":    plan.Bandwidth,
+			"monthly_cost": plan.MonthlyCost,
+			"type":         plan.Type,
+			"locations":    plan.Locations,
+		})
+	}
+
+	if regionOk {
+		d.SetId("bare-metal-plans-" + region.(string))
+	} else {
+		d.SetId("bare-metal-plans")
+	}
+	if err := d.Set("plans", planList); err != nil {
+		return diag.Errorf("error setting `plans`: %v", err)
+	}
+
+	return nil
+}
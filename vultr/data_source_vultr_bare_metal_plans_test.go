@@ -0,0 +1,28 @@
+package vultr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDataSourceVultrBareMetalPlansRegion(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/plans-metal" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"plans_metal":[{"id":"vbm-4c-32gb","cpu_count":4,"locations":["ewr","sea"]},{"id":"vbm-8c-132gb","cpu_count":8,"locations":["sea"]}],"meta":{"links":{"next":""}}}`)
+	})
+
+	d := dataSourceVultrBareMetalPlans().TestResourceData()
+	d.Set("region", "ewr")
+
+	if diags := dataSourceVultrBareMetalPlansRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("plans.#").(int) != 1 || d.Get("plans.0.id").(string) != "vbm-4c-32gb" {
+		t.Fatalf("expected only the plan offered in ewr, got %v", d.Get("plans"))
+	}
+}
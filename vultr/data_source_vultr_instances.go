@@ -2,10 +2,12 @@ package vultr
 
 import (
 	"context"
+	"regexp"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vultr/govultr/v2"
 )
 
@@ -14,6 +16,19 @@ func dataSourceVultrInstances() *schema.Resource {
 		ReadContext: dataSourceVultrInstancesRead,
 		Schema: map[string]*schema.Schema{
 			"filter": dataSourceFiltersSchema(),
+			"tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"label_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
 			"instances": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -169,90 +184,96 @@ func dataSourceVultrInstances() *schema.Resource {
 func dataSourceVultrInstancesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
-	filters, filtersOk := d.GetOk("filter")
+	var f []filter
+	if filters, filtersOk := d.GetOk("filter"); filtersOk {
+		f = buildVultrDataSourceFilter(filters.(*schema.Set))
+	}
 
-	if !filtersOk {
-		return diag.Errorf("issue with filter: %v", filtersOk)
+	var labelRegex *regexp.Regexp
+	if v, ok := d.GetOk("label_regex"); ok {
+		labelRegex = regexp.MustCompile(v.(string))
+	}
+
+	// tag and region are filtered by the API, which keeps the number of pages
+	// down on large accounts.
+	var servers []govultr.Instance
+	err := listAll(func(options *govultr.ListOptions) (*govultr.Meta, error) {
+		options.Tag = d.Get("tag").(string)
+		options.Region = d.Get("region").(string)
+		page, meta, err := client.Instance.List(ctx, options)
+		servers = append(servers, page...)
+		return meta, err
+	})
+	if err != nil {
+		return diag.Errorf("error getting servers: %v", err)
 	}
 
 	serverList := make([]interface{}, 0)
-	f := buildVultrDataSourceFilter(filters.(*schema.Set))
-	options := &govultr.ListOptions{}
-	for {
-		servers, meta, err := client.Instance.List(ctx, options)
-		if err != nil {
-			return diag.Errorf("error getting servers: %v", err)
+	for _, server := range servers {
+		if labelRegex != nil && !labelRegex.MatchString(server.Label) {
+			continue
 		}
 
-		for _, server := range servers {
-			// we need convert the a struct INTO a map so we can easily manipulate the data here
-			sm, err := structToMap(server)
+		// we need convert the a struct INTO a map so we can easily manipulate the data here
+		sm, err := structToMap(server)
 
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if filterLoop(f, sm) {
+			schedule, err := client.Instance.GetBackupSchedule(ctx, server.ID)
 			if err != nil {
-				return diag.FromErr(err)
+				return diag.Errorf("error getting backup schedule: %v", err)
 			}
 
-			if filterLoop(f, sm) {
-				schedule, err := client.Instance.GetBackupSchedule(ctx, server.ID)
-				if err != nil {
-					return diag.Errorf("error getting backup schedule: %v", err)
-				}
-
-				bsInfo := map[string]interface{}{
-					"type": schedule.Type,
-					"hour": strconv.Itoa(schedule.Hour),
-					"dom":  strconv.Itoa(schedule.Dom),
-					"dow":  strconv.Itoa(schedule.Dow),
-				}
-
-				vpcs, err := getVPCs(client, server.ID)
-				if err != nil {
-					return diag.Errorf(err.Error())
-				}
+			bsInfo := map[string]interface{}{
+				"type": schedule.Type,
+				"hour": strconv.Itoa(schedule.Hour),
+				"dom":  strconv.Itoa(schedule.Dom),
+				"dow":  strconv.Itoa(schedule.Dow),
+			}
 
-				serverList = append(serverList, map[string]interface{}{
-					"id":                  server.ID,
-					"os":                  server.Os,
-					"ram":                 server.RAM,
-					"disk":                server.Disk,
-					"main_ip":             server.MainIP,
-					"vcpu_count":          server.VCPUCount,
-					"region":              server.Region,
-					"date_created":        server.DateCreated,
-					"allowed_bandwidth":   server.AllowedBandwidth,
-					"netmask_v4":          server.NetmaskV4,
-					"gateway_v4":          server.GatewayV4,
-					"status":              server.Status,
-					"power_status":        server.PowerStatus,
-					"server_status":       server.ServerStatus,
-					"plan":                server.Plan,
-					"label":               server.Label,
-					"internal_ip":         server.InternalIP,
-					"kvm":                 server.KVM,
-					"tag":                 server.Tag,
-					"tags":                server.Tags,
-					"os_id":               server.OsID,
-					"app_id":              server.AppID,
-					"image_id":            server.ImageID,
-					"firewall_group_id":   server.FirewallGroupID,
-					"v6_network":          server.V6Network,
-					"v6_main_ip":          server.V6MainIP,
-					"v6_network_size":     server.V6NetworkSize,
-					"features":            server.Features,
-					"hostname":            server.Hostname,
-					"backups":             backupStatus(schedule.Enabled),
-					"backups_schedule":    bsInfo,
-					"private_network_ids": vpcs,
-					"vpc_ids":             vpcs,
-				})
+			vpcs, err := getVPCs(client, server.ID)
+			if err != nil {
+				return diag.Errorf(err.Error())
 			}
-		}
 
-		if meta.Links.Next == "" {
-			break
-		} else {
-			options.Cursor = meta.Links.Next
-			continue
+			serverList = append(serverList, map[string]interface{}{
+				"id":                  server.ID,
+				"os":                  server.Os,
+				"ram":                 server.RAM,
+				"disk":                server.Disk,
+				"main_ip":             server.MainIP,
+				"vcpu_count":          server.VCPUCount,
+				"region":              server.Region,
+				"date_created":        server.DateCreated,
+				"allowed_bandwidth":   server.AllowedBandwidth,
+				"netmask_v4":          server.NetmaskV4,
+				"gateway_v4":          server.GatewayV4,
+				"status":              server.Status,
+				"power_status":        server.PowerStatus,
+				"server_status":       server.ServerStatus,
+				"plan":                server.Plan,
+				"label":               server.Label,
+				"internal_ip":         server.InternalIP,
+				"kvm":                 server.KVM,
+				"tag":                 server.Tag,
+				"tags":                server.Tags,
+				"os_id":               server.OsID,
+				"app_id":              server.AppID,
+				"image_id":            server.ImageID,
+				"firewall_group_id":   server.FirewallGroupID,
+				"v6_network":          server.V6Network,
+				"v6_main_ip":          server.V6MainIP,
+				"v6_network_size":     server.V6NetworkSize,
+				"features":            server.Features,
+				"hostname":            server.Hostname,
+				"backups":             backupStatus(schedule.Enabled),
+				"backups_schedule":    bsInfo,
+				"private_network_ids": vpcs,
+				"vpc_ids":             vpcs,
+			})
 		}
 	}
 
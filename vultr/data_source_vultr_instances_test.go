@@ -1,7 +1,9 @@
 package vultr
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -49,6 +51,37 @@ func TestAccVultrInstances(t *testing.T) {
 	})
 }
 
+func TestDataSourceVultrInstancesTagRegionAndLabelRegex(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/instances":
+			if q := r.URL.Query(); q.Get("tag") != "web" || q.Get("region") != "ewr" {
+				t.Errorf("expected tag and region to be sent to the API, got %q", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `{"instances":[{"id":"web-1","label":"web-1","main_ip":"192.0.2.1"},{"id":"worker-1","label":"worker-1"}],"meta":{"links":{"next":""}}}`)
+		case "/v2/instances/web-1/backup-schedule":
+			fmt.Fprint(w, `{"backup_schedule":{"enabled":false}}`)
+		case "/v2/instances/web-1/vpcs":
+			fmt.Fprint(w, `{"vpcs":[],"meta":{"links":{"next":""}}}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	d := dataSourceVultrInstances().TestResourceData()
+	d.Set("tag", "web")
+	d.Set("region", "ewr")
+	d.Set("label_regex", "^web-")
+
+	if diags := dataSourceVultrInstancesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("instances.#").(int) != 1 || d.Get("instances.0.id").(string) != "web-1" {
+		t.Fatalf("expected only web-1, got %v", d.Get("instances"))
+	}
+}
+
 func testAccCheckVultrInstances(label string) string {
 	return fmt.Sprintf(`
 		resource "vultr_instance" "test" {
@@ -14,8 +14,10 @@ func dataSourceVultrKubernetes() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"filter": dataSourceFiltersSchema(),
 			"id": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"filter"},
 			},
 			"label": {
 				Type:     schema.TypeString,
@@ -71,66 +73,77 @@ func dataSourceVultrKubernetes() *schema.Resource {
 func dataSourceVultrKubernetesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
-	filters, filtersOk := d.GetOk("filter")
-	if !filtersOk {
-		return diag.Errorf("issue with filter: %v", filtersOk)
-	}
-
-	var k8List []govultr.Cluster
-	f := buildVultrDataSourceFilter(filters.(*schema.Set))
-	options := &govultr.ListOptions{}
-	for {
-		k8s, meta, err := client.Kubernetes.ListClusters(ctx, options)
+	var cluster *govultr.Cluster
+	if id, idOk := d.GetOk("id"); idOk {
+		vke, err := client.Kubernetes.GetCluster(ctx, id.(string))
 		if err != nil {
-			return diag.Errorf("error getting kubernetes")
+			return diag.Errorf("error getting kubernetes cluster (%s): %v", id, err)
+		}
+		cluster = vke
+	} else {
+		filters, filtersOk := d.GetOk("filter")
+		if !filtersOk {
+			return diag.Errorf("either id or filter must be provided")
 		}
 
-		for _, k8 := range k8s {
-			sm, err := structToMap(k8)
-
+		var k8List []govultr.Cluster
+		f := buildVultrDataSourceFilter(filters.(*schema.Set))
+		options := &govultr.ListOptions{}
+		for {
+			k8s, meta, err := client.Kubernetes.ListClusters(ctx, options)
 			if err != nil {
-				return diag.FromErr(err)
+				return diag.Errorf("error getting kubernetes")
 			}
 
-			if filterLoop(f, sm) {
-				k8List = append(k8List, k8)
+			for _, k8 := range k8s {
+				sm, err := structToMap(k8)
+
+				if err != nil {
+					return diag.FromErr(err)
+				}
+
+				if filterLoop(f, sm) {
+					k8List = append(k8List, k8)
+				}
+			}
+
+			if meta.Links.Next == "" {
+				break
+			} else {
+				options.Cursor = meta.Links.Next
+				continue
 			}
 		}
 
-		if meta.Links.Next == "" {
-			break
-		} else {
-			options.Cursor = meta.Links.Next
-			continue
+		if len(k8List) > 1 {
+			return diag.Errorf("your search returned too many results. Please refine your search to be more specific")
 		}
-	}
 
-	if len(k8List) > 1 {
-		return diag.Errorf("your search returned too many results. Please refine your search to be more specific")
-	}
+		if len(k8List) < 1 {
+			return diag.Errorf("no results were found")
+		}
 
-	if len(k8List) < 1 {
-		return diag.Errorf("no results were found")
+		cluster = &k8List[0]
 	}
 
-	kubeConfig, err := client.Kubernetes.GetKubeConfig(ctx, k8List[0].ID)
+	kubeConfig, err := client.Kubernetes.GetKubeConfig(ctx, cluster.ID)
 	if err != nil {
 		return diag.Errorf("error getting kubeconfig")
 	}
 
-	d.SetId(k8List[0].ID)
-	d.Set("label", k8List[0].Label)
-	d.Set("date_created", k8List[0].DateCreated)
-	d.Set("cluster_subnet", k8List[0].ClusterSubnet)
-	d.Set("service_subnet", k8List[0].ServiceSubnet)
-	d.Set("ip", k8List[0].IP)
-	d.Set("endpoint", k8List[0].Endpoint)
-	d.Set("version", k8List[0].Version)
-	d.Set("region", k8List[0].Region)
-	d.Set("status", k8List[0].Status)
+	d.SetId(cluster.ID)
+	d.Set("label", cluster.Label)
+	d.Set("date_created", cluster.DateCreated)
+	d.Set("cluster_subnet", cluster.ClusterSubnet)
+	d.Set("service_subnet", cluster.ServiceSubnet)
+	d.Set("ip", cluster.IP)
+	d.Set("endpoint", cluster.Endpoint)
+	d.Set("version", cluster.Version)
+	d.Set("region", cluster.Region)
+	d.Set("status", cluster.Status)
 	d.Set("kube_config", kubeConfig.KubeConfig)
 
-	if err := d.Set("node_pools", flattenNodePools(k8List[0].NodePools)); err != nil {
+	if err := d.Set("node_pools", flattenNodePools(cluster.NodePools)); err != nil {
 		return diag.FromErr(err)
 	}
 
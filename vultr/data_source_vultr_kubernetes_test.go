@@ -31,6 +31,28 @@ func TestAccVultrKubernetes(t *testing.T) {
 	})
 }
 
+func TestAccVultrKubernetesByID(t *testing.T) {
+	skipCI(t)
+
+	rLabel := acctest.RandomWithPrefix("tf-test-k8")
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVultrKubernetesByID(rLabel),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.vultr_kubernetes.k8", "id", "vultr_kubernetes.test", "id"),
+					resource.TestCheckResourceAttr("data.vultr_kubernetes.k8", "label", rLabel),
+					resource.TestCheckResourceAttrSet("data.vultr_kubernetes.k8", "endpoint"),
+					resource.TestCheckResourceAttrSet("data.vultr_kubernetes.k8", "kube_config"),
+					resource.TestCheckResourceAttr("data.vultr_kubernetes.k8", "node_pools.#", "1"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckVultrKubernetes(label string) string {
 	return fmt.Sprintf(`
 		resource "vultr_kubernetes" "test" {
@@ -52,3 +74,22 @@ func testAccCheckVultrKubernetes(label string) string {
 			}
 		}`, label)
 }
+
+func testAccCheckVultrKubernetesByID(label string) string {
+	return fmt.Sprintf(`
+		resource "vultr_kubernetes" "test" {
+			region = "ewr"
+			label = "%s"
+			version = "v1.24.3+2"
+
+			node_pools {
+				node_quantity = 1
+				plan = "vc2-2c-4gb"
+    			label = "tf-test-label"
+			}
+		}
+
+		data "vultr_kubernetes" "k8" {
+			id = vultr_kubernetes.test.id
+		}`, label)
+}
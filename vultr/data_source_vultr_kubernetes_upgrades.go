@@ -0,0 +1,60 @@
+package vultr
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceVultrKubernetesUpgrades() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceVultrKubernetesUpgradesRead,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"upgrades": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"latest": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceVultrKubernetesUpgradesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).govultrClient()
+
+	clusterID := d.Get("cluster_id").(string)
+	upgrades, err := client.Kubernetes.GetUpgrades(ctx, clusterID)
+	if err != nil {
+		return diag.Errorf("error getting upgrades for kubernetes cluster %s: %v", clusterID, err)
+	}
+
+	sorted, err := sortVKEVersions(upgrades)
+	if err != nil {
+		return diag.Errorf("error sorting kubernetes upgrades: %v", err)
+	}
+
+	d.SetId(clusterID)
+	if err := d.Set("upgrades", sorted); err != nil {
+		return diag.Errorf("error setting `upgrades`: %v", err)
+	}
+
+	// A cluster on the newest version has no upgrades
+	latest := ""
+	if len(sorted) != 0 {
+		latest = sorted[0]
+	}
+	d.Set("latest", latest)
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package vultr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDataSourceVultrKubernetesUpgradesRead(t *testing.T) {
+	upgrades := `["v1.24.4+1","v1.25.4+1"]`
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/kubernetes/clusters/cluster/available-upgrades" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		fmt.Fprintf(w, `{"available_upgrades":%s}`, upgrades)
+	})
+
+	d := dataSourceVultrKubernetesUpgrades().TestResourceData()
+	d.Set("cluster_id", "cluster")
+	if diags := dataSourceVultrKubernetesUpgradesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	expected := []interface{}{"v1.25.4+1", "v1.24.4+1"}
+	if got := d.Get("upgrades").([]interface{}); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected upgrades %v, got %v", expected, got)
+	}
+	if d.Get("latest").(string) != "v1.25.4+1" {
+		t.Errorf("expected latest v1.25.4+1, got %q", d.Get("latest"))
+	}
+
+	// A cluster on the newest version
+	upgrades = `[]`
+	if diags := dataSourceVultrKubernetesUpgradesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if len(d.Get("upgrades").([]interface{})) != 0 || d.Get("latest").(string) != "" {
+		t.Errorf("expected no upgrades, got %v and latest %q", d.Get("upgrades"), d.Get("latest"))
+	}
+}
@@ -0,0 +1,77 @@
+package vultr
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceVultrKubernetesVersions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceVultrKubernetesVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"versions": {
+				Type:     schema.TypeList,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
+			"latest": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceVultrKubernetesVersionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).govultrClient()
+
+	versions, err := client.Kubernetes.GetVersions(ctx)
+	if err != nil {
+		return diag.Errorf("error getting kubernetes versions: %v", err)
+	}
+
+	if len(versions.Versions) < 1 {
+		return diag.Errorf("no kubernetes versions were found")
+	}
+
+	sorted, err := sortVKEVersions(versions.Versions)
+	if err != nil {
+		return diag.Errorf("error sorting kubernetes versions: %v", err)
+	}
+
+	d.SetId("kubernetes_versions")
+	if err := d.Set("versions", sorted); err != nil {
+		return diag.Errorf("error setting `versions`: %v", err)
+	}
+	d.Set("latest", sorted[0])
+
+	return nil
+}
+
+// sortVKEVersions returns a copy of versions ordered from newest to oldest.
+func sortVKEVersions(versions []string) ([]string, error) {
+	parsed := make(map[string][4]int, len(versions))
+	for _, v := range versions {
+		p, err := parseVKEVersion(v)
+		if err != nil {
+			return nil, err
+		}
+		parsed[v] = p
+	}
+
+	sorted := append([]string(nil), versions...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := parsed[sorted[i]], parsed[sorted[j]]
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] > b[k]
+			}
+		}
+		return false
+	})
+
+	return sorted, nil
+}
@@ -0,0 +1,45 @@
+package vultr
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVultrKubernetesVersions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVultrKubernetesVersions(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vultr_kubernetes_versions.current", "versions.#"),
+					resource.TestCheckResourceAttrSet("data.vultr_kubernetes_versions.current", "latest"),
+					resource.TestCheckResourceAttrPair("data.vultr_kubernetes_versions.current", "latest", "data.vultr_kubernetes_versions.current", "versions.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestSortVKEVersions(t *testing.T) {
+	sorted, err := sortVKEVersions([]string{"v1.23.5+1", "v1.24.3+2", "v1.24.3+10", "v1.22.9+3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"v1.24.3+10", "v1.24.3+2", "v1.23.5+1", "v1.22.9+3"}
+	if !reflect.DeepEqual(sorted, expected) {
+		t.Fatalf("expected %v, got %v", expected, sorted)
+	}
+
+	if _, err := sortVKEVersions([]string{"latest"}); err == nil {
+		t.Fatal("expected an error for an invalid version")
+	}
+}
+
+func testAccVultrKubernetesVersions() string {
+	return `data "vultr_kubernetes_versions" "current" {}`
+}
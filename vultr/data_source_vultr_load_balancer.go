@@ -14,6 +14,12 @@ func dataSourceVultrLoadBalancer() *schema.Resource {
 		ReadContext: dataSourceVultrLoadBalancerRead,
 		Schema: map[string]*schema.Schema{
 			"filter": dataSourceFiltersSchema(),
+			"id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"filter", "label"},
+			},
 			"date_created": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -27,8 +33,10 @@ func dataSourceVultrLoadBalancer() *schema.Resource {
 				Computed: true,
 			},
 			"label": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
 			},
 			"forwarding_rules": {
 				Type:     schema.TypeList,
@@ -93,64 +101,78 @@ func dataSourceVultrLoadBalancer() *schema.Resource {
 func dataSourceVultrLoadBalancerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
-	filters, filtersOk := d.GetOk("filter")
-
-	if !filtersOk {
-		return diag.Errorf("issue with filter: %v", filtersOk)
-	}
-	var lbList []govultr.LoadBalancer
-	f := buildVultrDataSourceFilter(filters.(*schema.Set))
-	options := &govultr.ListOptions{}
-	for {
-		lbs, meta, err := client.LoadBalancer.List(ctx, options)
+	var lb *govultr.LoadBalancer
+	if id, idOk := d.GetOk("id"); idOk {
+		lbByID, err := client.LoadBalancer.Get(ctx, id.(string))
 		if err != nil {
-			return diag.Errorf("error getting load balancer: %v", err)
+			return diag.Errorf("error getting load balancer (%s): %v", id, err)
+		}
+		lb = lbByID
+	} else {
+		var f []filter
+		if filters, filtersOk := d.GetOk("filter"); filtersOk {
+			f = buildVultrDataSourceFilter(filters.(*schema.Set))
+		}
+		label, labelOk := d.GetOk("label")
+		if !labelOk && len(f) == 0 {
+			return diag.Errorf("one of id, label or filter must be provided")
 		}
 
-		for _, b := range lbs {
-			sm, err := structToMap(b)
-
+		var lbList []govultr.LoadBalancer
+		err := listAll(func(options *govultr.ListOptions) (*govultr.Meta, error) {
+			lbs, meta, err := client.LoadBalancer.List(ctx, options)
 			if err != nil {
-				return diag.FromErr(err)
+				return nil, err
 			}
 
-			if filterLoop(f, sm) {
-				lbList = append(lbList, b)
+			for _, b := range lbs {
+				if labelOk && b.Label != label.(string) {
+					continue
+				}
+
+				sm, err := structToMap(b)
+				if err != nil {
+					return nil, err
+				}
+
+				if filterLoop(f, sm) {
+					lbList = append(lbList, b)
+				}
 			}
+			return meta, nil
+		})
+		if err != nil {
+			return diag.Errorf("error getting load balancer: %v", err)
 		}
 
-		if meta.Links.Next == "" {
-			break
-		} else {
-			options.Cursor = meta.Links.Next
-			continue
+		if len(lbList) > 1 {
+			return diag.Errorf("your search returned too many results. Please refine your search to be more specific")
+		}
+
+		if len(lbList) < 1 {
+			return diag.Errorf("no results were found")
 		}
-	}
-	if len(lbList) > 1 {
-		return diag.Errorf("your search returned too many results. Please refine your search to be more specific")
-	}
 
-	if len(lbList) < 1 {
-		return diag.Errorf("no results were found")
+		lb = &lbList[0]
 	}
 
-	d.SetId(lbList[0].ID)
-	d.Set("has_ssl", lbList[0].SSLInfo)
-	d.Set("attached_instances", lbList[0].Instances)
-	d.Set("balancing_algorithm", lbList[0].GenericInfo.BalancingAlgorithm)
-	d.Set("ssl_redirect", lbList[0].GenericInfo.SSLRedirect)
-	d.Set("proxy_protocol", lbList[0].GenericInfo.ProxyProtocol)
-	d.Set("cookie_name", lbList[0].GenericInfo.StickySessions.CookieName)
-	d.Set("date_created", lbList[0].DateCreated)
-	d.Set("status", lbList[0].Status)
-	d.Set("region", lbList[0].Region)
-	d.Set("label", lbList[0].Label)
-	d.Set("ipv4", lbList[0].IPV4)
-	d.Set("ipv6", lbList[0].IPV6)
-	d.Set("private_network", lbList[0].GenericInfo.PrivateNetwork)
+	d.SetId(lb.ID)
+	d.Set("has_ssl", lb.SSLInfo)
+	d.Set("attached_instances", lb.Instances)
+	d.Set("balancing_algorithm", lb.GenericInfo.BalancingAlgorithm)
+	d.Set("ssl_redirect", lb.GenericInfo.SSLRedirect)
+	d.Set("proxy_protocol", lb.GenericInfo.ProxyProtocol)
+	d.Set("cookie_name", lb.GenericInfo.StickySessions.CookieName)
+	d.Set("date_created", lb.DateCreated)
+	d.Set("status", lb.Status)
+	d.Set("region", lb.Region)
+	d.Set("label", lb.Label)
+	d.Set("ipv4", lb.IPV4)
+	d.Set("ipv6", lb.IPV6)
+	d.Set("private_network", lb.GenericInfo.PrivateNetwork)
 
 	var rulesList []map[string]interface{}
-	for _, rules := range lbList[0].ForwardingRules {
+	for _, rules := range lb.ForwardingRules {
 		rule := map[string]interface{}{
 			"rule_id":           rules.RuleID,
 			"frontend_protocol": rules.FrontendProtocol,
@@ -166,13 +188,13 @@ func dataSourceVultrLoadBalancerRead(ctx context.Context, d *schema.ResourceData
 	}
 
 	hcInfo := map[string]interface{}{
-		"protocol":            lbList[0].HealthCheck.Protocol,
-		"port":                strconv.Itoa(lbList[0].HealthCheck.Port),
-		"path":                lbList[0].HealthCheck.Path,
-		"check_interval":      strconv.Itoa(lbList[0].HealthCheck.CheckInterval),
-		"response_timeout":    strconv.Itoa(lbList[0].HealthCheck.ResponseTimeout),
-		"unhealthy_threshold": strconv.Itoa(lbList[0].HealthCheck.UnhealthyThreshold),
-		"healthy_threshold":   strconv.Itoa(lbList[0].HealthCheck.HealthyThreshold),
+		"protocol":            lb.HealthCheck.Protocol,
+		"port":                strconv.Itoa(lb.HealthCheck.Port),
+		"path":                lb.HealthCheck.Path,
+		"check_interval":      strconv.Itoa(lb.HealthCheck.CheckInterval),
+		"response_timeout":    strconv.Itoa(lb.HealthCheck.ResponseTimeout),
+		"unhealthy_threshold": strconv.Itoa(lb.HealthCheck.UnhealthyThreshold),
+		"healthy_threshold":   strconv.Itoa(lb.HealthCheck.HealthyThreshold),
 	}
 
 	if err := d.Set("health_check", hcInfo); err != nil {
@@ -180,7 +202,7 @@ func dataSourceVultrLoadBalancerRead(ctx context.Context, d *schema.ResourceData
 	}
 
 	var fwrRules []map[string]interface{}
-	for _, rules := range lbList[0].FirewallRules {
+	for _, rules := range lb.FirewallRules {
 		rule := map[string]interface{}{
 			"id":      rules.RuleID,
 			"ip_type": rules.IPType,
@@ -1,7 +1,9 @@
 package vultr
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -28,6 +30,30 @@ func TestAccVultrLoadBalancer(t *testing.T) {
 	})
 }
 
+func TestDataSourceVultrLoadBalancerByLabel(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/load-balancers" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"load_balancers":[{"id":"lb-1","label":"web-lb-old","ipv4":"192.0.2.1"},{"id":"lb-2","label":"web-lb","ipv4":"192.0.2.2","ipv6":"2001:db8::2","status":"active","instances":["instance-1"],"generic_info":{"balancing_algorithm":"roundrobin","sticky_sessions":{}},"health_check":{"protocol":"http","port":80}}],"meta":{"links":{"next":""}}}`)
+	})
+
+	d := dataSourceVultrLoadBalancer().TestResourceData()
+	d.Set("label", "web-lb")
+
+	if diags := dataSourceVultrLoadBalancerRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "lb-2" || d.Get("ipv4").(string) != "192.0.2.2" || d.Get("ipv6").(string) != "2001:db8::2" {
+		t.Fatalf("expected the load balancer labeled web-lb, got %s with ipv4 %v", d.Id(), d.Get("ipv4"))
+	}
+
+	if d.Get("attached_instances.0").(string) != "instance-1" {
+		t.Fatalf("expected attached instance-1, got %v", d.Get("attached_instances"))
+	}
+}
+
 func testAccCheckVultrLoadBalancer(label string) string {
 	return fmt.Sprintf(`
 		resource "vultr_load_balancer" "test" {
@@ -13,6 +13,12 @@ func dataSourceVultrObjectStorage() *schema.Resource {
 		ReadContext: dataSourceVultrObjectStorageRead,
 		Schema: map[string]*schema.Schema{
 			"filter": dataSourceFiltersSchema(),
+			"id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"filter", "label"},
+			},
 			"date_created": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -22,8 +28,10 @@ func dataSourceVultrObjectStorage() *schema.Resource {
 				Computed: true,
 			},
 			"label": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"id"},
 			},
 			"location": {
 				Type:     schema.TypeString,
@@ -58,57 +66,71 @@ func dataSourceVultrObjectStorage() *schema.Resource {
 func dataSourceVultrObjectStorageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
-	filters, filtersOK := d.GetOk("filter")
-	if !filtersOK {
-		return diag.Errorf("issue with filter: %v", filtersOK)
-	}
-
-	objStoreList := []govultr.ObjectStorage{}
-	f := buildVultrDataSourceFilter(filters.(*schema.Set))
-	options := &govultr.ListOptions{}
-
-	for {
-		objectStorages, meta, err := client.ObjectStorage.List(ctx, options)
+	var objStore *govultr.ObjectStorage
+	if id, idOk := d.GetOk("id"); idOk {
+		objStoreByID, err := client.ObjectStorage.Get(ctx, id.(string))
 		if err != nil {
-			return diag.Errorf("error getting object storage list: %v", filtersOK)
+			return diag.Errorf("error getting object storage (%s): %v", id, err)
+		}
+		objStore = objStoreByID
+	} else {
+		var f []filter
+		if filters, filtersOK := d.GetOk("filter"); filtersOK {
+			f = buildVultrDataSourceFilter(filters.(*schema.Set))
+		}
+		label, labelOk := d.GetOk("label")
+		if !labelOk && len(f) == 0 {
+			return diag.Errorf("one of id, label or filter must be provided")
 		}
 
-		for _, n := range objectStorages {
-			// we need convert the a struct INTO a map so we can easily manipulate the data here
-			sm, err := structToMap(n)
-
+		objStoreList := []govultr.ObjectStorage{}
+		err := listAll(func(options *govultr.ListOptions) (*govultr.Meta, error) {
+			objectStorages, meta, err := client.ObjectStorage.List(ctx, options)
 			if err != nil {
-				return diag.FromErr(err)
+				return nil, err
 			}
 
-			if filterLoop(f, sm) {
-				objStoreList = append(objStoreList, n)
+			for _, n := range objectStorages {
+				if labelOk && n.Label != label.(string) {
+					continue
+				}
+
+				// we need convert the a struct INTO a map so we can easily manipulate the data here
+				sm, err := structToMap(n)
+				if err != nil {
+					return nil, err
+				}
+
+				if filterLoop(f, sm) {
+					objStoreList = append(objStoreList, n)
+				}
 			}
+			return meta, nil
+		})
+		if err != nil {
+			return diag.Errorf("error getting object storage list: %v", err)
 		}
 
-		if meta.Links.Next == "" {
-			break
-		} else {
-			options.Cursor = meta.Links.Next
-			continue
+		if len(objStoreList) > 1 {
+			return diag.Errorf("your search returned too many results. Please refine your search to be more specific")
+		}
+
+		if len(objStoreList) < 1 {
+			return diag.Errorf("no results were found")
 		}
-	}
-	if len(objStoreList) > 1 {
-		return diag.Errorf("your search returned too many results. Please refine your search to be more specific")
-	}
 
-	if len(objStoreList) < 1 {
-		return diag.Errorf("no results were found")
+		objStore = &objStoreList[0]
 	}
-	d.SetId(objStoreList[0].ID)
-	d.Set("date_created", objStoreList[0].DateCreated)
-	d.Set("cluster_id", objStoreList[0].ObjectStoreClusterID)
-	d.Set("label", objStoreList[0].Label)
-	d.Set("region", objStoreList[0].Region)
-	d.Set("location", objStoreList[0].Location)
-	d.Set("status", objStoreList[0].Status)
-	d.Set("s3_hostname", objStoreList[0].S3Hostname)
-	d.Set("s3_access_key", objStoreList[0].S3AccessKey)
-	d.Set("s3_secret_key", objStoreList[0].S3SecretKey)
+
+	d.SetId(objStore.ID)
+	d.Set("date_created", objStore.DateCreated)
+	d.Set("cluster_id", objStore.ObjectStoreClusterID)
+	d.Set("label", objStore.Label)
+	d.Set("region", objStore.Region)
+	d.Set("location", objStore.Location)
+	d.Set("status", objStore.Status)
+	d.Set("s3_hostname", objStore.S3Hostname)
+	d.Set("s3_access_key", objStore.S3AccessKey)
+	d.Set("s3_secret_key", objStore.S3SecretKey)
 	return nil
 }
@@ -49,7 +49,7 @@ func dataSourceVultrObjectStorageClustersRead(ctx context.Context, d *schema.Res
 	for {
 		clusters, meta, err := client.ObjectStorage.ListCluster(ctx, options)
 		if err != nil {
-			return diag.Errorf("Error getting plans: %v", err)
+			return diag.Errorf("error getting object storage clusters: %v", err)
 		}
 
 		for _, a := range clusters {
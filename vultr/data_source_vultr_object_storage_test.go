@@ -1,13 +1,39 @@
 package vultr
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
+func TestDataSourceVultrObjectStorageByLabel(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/object-storage" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"object_storages":[{"id":"os-1","label":"backups-old"},{"id":"os-2","label":"backups","status":"active","s3_hostname":"ewr1.vultrobjects.com","s3_access_key":"access","s3_secret_key":"secret","cluster_id":2}],"meta":{"links":{"next":""}}}`)
+	})
+
+	d := dataSourceVultrObjectStorage().TestResourceData()
+	d.Set("label", "backups")
+
+	if diags := dataSourceVultrObjectStorageRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "os-2" || d.Get("s3_hostname").(string) != "ewr1.vultrobjects.com" || d.Get("s3_secret_key").(string) != "secret" {
+		t.Fatalf("expected the subscription labeled backups, got %s with hostname %v", d.Id(), d.Get("s3_hostname"))
+	}
+
+	if d.Get("cluster_id").(int) != 2 {
+		t.Fatalf("expected cluster_id 2, got %v", d.Get("cluster_id"))
+	}
+}
+
 func TestAccVultrObjectStorage(t *testing.T) {
 	t.Parallel()
 	rLabel := acctest.RandomWithPrefix("tf-test-s3")
@@ -38,32 +38,27 @@ func dataSourceVultrOSRead(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.Errorf("issue with filter: %v", filtersOk)
 	}
 
+	var os []govultr.OS
+	err := listAll(func(options *govultr.ListOptions) (*govultr.Meta, error) {
+		page, meta, err := client.OS.List(ctx, options)
+		os = append(os, page...)
+		return meta, err
+	})
+	if err != nil {
+		return diag.Errorf("error getting os list: %v", err)
+	}
+
 	osList := []govultr.OS{}
 	f := buildVultrDataSourceFilter(filters.(*schema.Set))
-	options := &govultr.ListOptions{}
-	for {
-		os, meta, err := client.OS.List(ctx, options)
-		if err != nil {
-			return diag.Errorf("error getting os list: %v", err)
-		}
+	for _, o := range os {
+		sm, err := structToMap(o)
 
-		for _, o := range os {
-			sm, err := structToMap(o)
-
-			if err != nil {
-				return diag.FromErr(err)
-			}
-
-			if filterLoop(f, sm) {
-				osList = append(osList, o)
-			}
+		if err != nil {
+			return diag.FromErr(err)
 		}
 
-		if meta.Links.Next == "" {
-			break
-		} else {
-			options.Cursor = meta.Links.Next
-			continue
+		if filterLoop(f, sm) {
+			osList = append(osList, o)
 		}
 	}
 
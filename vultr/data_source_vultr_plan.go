@@ -2,6 +2,7 @@ package vultr
 
 import (
 	"context"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -13,6 +14,15 @@ func dataSourceVultrPlan() *schema.Resource {
 		ReadContext: dataSourceVultrPlanRead,
 		Schema: map[string]*schema.Schema{
 			"filter": dataSourceFiltersSchema(),
+			"min_vcpu_count": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"cheapest": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"vcpu_count": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -66,43 +76,43 @@ func dataSourceVultrPlanRead(ctx context.Context, d *schema.ResourceData, meta i
 		return diag.Errorf("issue with filter: %v", filtersOk)
 	}
 
+	var plans []govultr.Plan
+	err := listAll(func(options *govultr.ListOptions) (*govultr.Meta, error) {
+		page, meta, err := client.Plan.List(ctx, "", options)
+		plans = append(plans, page...)
+		return meta, err
+	})
+	if err != nil {
+		return diag.Errorf("Error getting plans: %v", err)
+	}
+
 	planList := []govultr.Plan{}
 	f := buildVultrDataSourceFilter(filters.(*schema.Set))
-	options := &govultr.ListOptions{}
+	minVCPU := d.Get("min_vcpu_count").(int)
+	for _, a := range plans {
+		// we need convert the a struct INTO a map so we can easily manipulate the data here
+		sm, err := structToMap(a)
 
-	for {
-		plans, meta, err := client.Plan.List(ctx, "", options)
 		if err != nil {
-			return diag.Errorf("Error getting plans: %v", err)
+			return diag.FromErr(err)
 		}
 
-		for _, a := range plans {
-			// we need convert the a struct INTO a map so we can easily manipulate the data here
-			sm, err := structToMap(a)
-
-			if err != nil {
-				return diag.FromErr(err)
-			}
-
-			if filterLoop(f, sm) {
-				planList = append(planList, a)
-			}
+		if filterLoop(f, sm) && a.VCPUCount >= minVCPU {
+			planList = append(planList, a)
 		}
+	}
 
-		if meta.Links.Next == "" {
-			break
-		} else {
-			options.Cursor = meta.Links.Next
-			continue
-		}
+	if len(planList) < 1 {
+		return diag.Errorf("no results were found")
 	}
 
-	if len(planList) > 1 {
-		return diag.Errorf("your search returned too many results. Please refine your search to be more specific")
+	if d.Get("cheapest").(bool) {
+		sortPlansByCost(planList)
+		planList = planList[:1]
 	}
 
-	if len(planList) < 1 {
-		return diag.Errorf("no results were found")
+	if len(planList) > 1 {
+		return diag.Errorf("your search returned too many results. Please refine your search to be more specific")
 	}
 
 	d.SetId(planList[0].ID)
@@ -120,3 +130,11 @@ func dataSourceVultrPlanRead(ctx context.Context, d *schema.ResourceData, meta i
 	}
 	return nil
 }
+
+// sortPlansByCost orders plans from the lowest to the highest monthly cost,
+// keeping the API order for plans with the same price.
+func sortPlansByCost(plans []govultr.Plan) {
+	sort.SliceStable(plans, func(i, j int) bool {
+		return plans[i].MonthlyCost < plans[j].MonthlyCost
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vultr/govultr/v2"
 )
 
 func TestAccVultrPlan(t *testing.T) {
@@ -28,6 +29,40 @@ func TestAccVultrPlan(t *testing.T) {
 	})
 }
 
+func TestAccVultrPlanCheapest(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckVultrPlanCheapest(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vultr_plan.cheapest", "vcpu_count"),
+					resource.TestCheckResourceAttrSet("data.vultr_plan.cheapest", "monthly_cost"),
+				),
+			},
+		},
+	})
+}
+
+func TestSortPlansByCost(t *testing.T) {
+	plans := []govultr.Plan{
+		{ID: "vc2-4c-8gb", MonthlyCost: 40},
+		{ID: "vc2-2c-4gb", MonthlyCost: 20},
+		{ID: "vhf-2c-4gb", MonthlyCost: 24},
+		{ID: "vc2-2c-4gb-sc1", MonthlyCost: 20},
+	}
+
+	sortPlansByCost(plans)
+
+	expected := []string{"vc2-2c-4gb", "vc2-2c-4gb-sc1", "vhf-2c-4gb", "vc2-4c-8gb"}
+	for i, id := range expected {
+		if plans[i].ID != id {
+			t.Fatalf("expected plan %d to be %s, got %s", i, id, plans[i].ID)
+		}
+	}
+}
+
 func testAccCheckVultrPlan(name string) string {
 	return fmt.Sprintf(`
 		data "vultr_plan" "plan1gb" {
@@ -37,3 +72,16 @@ func testAccCheckVultrPlan(name string) string {
 			}
 		}`, name)
 }
+
+func testAccCheckVultrPlanCheapest() string {
+	return `
+		data "vultr_plan" "cheapest" {
+			min_vcpu_count = 2
+			cheapest       = true
+
+			filter {
+				name   = "locations"
+				values = ["ewr"]
+			}
+		}`
+}
@@ -43,33 +43,28 @@ func dataSourceVultrRegionRead(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("issue with filter: %v", filtersOk)
 	}
 
+	var regions []govultr.Region
+	err := listAll(func(options *govultr.ListOptions) (*govultr.Meta, error) {
+		page, meta, err := client.Region.List(ctx, options)
+		regions = append(regions, page...)
+		return meta, err
+	})
+	if err != nil {
+		return diag.Errorf("Error getting regions: %v", err)
+	}
+
 	regionList := []govultr.Region{}
 	f := buildVultrDataSourceFilter(filters.(*schema.Set))
-	options := &govultr.ListOptions{}
-	for {
-		regions, meta, err := client.Region.List(ctx, options)
-		if err != nil {
-			return diag.Errorf("Error getting regions: %v", err)
-		}
+	for _, a := range regions {
+		// we need convert the a struct INTO a map so we can easily manipulate the data here
+		sm, err := structToMap(a)
 
-		for _, a := range regions {
-			// we need convert the a struct INTO a map so we can easily manipulate the data here
-			sm, err := structToMap(a)
-
-			if err != nil {
-				return diag.FromErr(err)
-			}
-
-			if filterLoop(f, sm) {
-				regionList = append(regionList, a)
-			}
+		if err != nil {
+			return diag.FromErr(err)
 		}
 
-		if meta.Links.Next == "" {
-			break
-		} else {
-			options.Cursor = meta.Links.Next
-			continue
+		if filterLoop(f, sm) {
+			regionList = append(regionList, a)
 		}
 	}
 
@@ -2,9 +2,12 @@ package vultr
 
 import (
 	"context"
+	"regexp"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vultr/govultr/v2"
 )
 
@@ -13,6 +16,16 @@ func dataSourceVultrSnapshot() *schema.Resource {
 		ReadContext: dataSourceVultrSnapshotRead,
 		Schema: map[string]*schema.Schema{
 			"filter": dataSourceFiltersSchema(),
+			"description_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"most_recent": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"date_created": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -46,48 +59,60 @@ func dataSourceVultrSnapshotRead(ctx context.Context, d *schema.ResourceData, me
 	client := meta.(*Client).govultrClient()
 
 	filters, filtersOk := d.GetOk("filter")
+	regex, regexOk := d.GetOk("description_regex")
 
-	if !filtersOk {
-		return diag.Errorf("issue with filter: %v", filtersOk)
+	if !filtersOk && !regexOk {
+		return diag.Errorf("one of filter or description_regex must be set")
 	}
 
-	var snapshotList []govultr.Snapshot
-	f := buildVultrDataSourceFilter(filters.(*schema.Set))
-	options := &govultr.ListOptions{}
-
-	for {
-		snapshots, meta, err := client.Snapshot.List(ctx, options)
-		if err != nil {
-			return diag.Errorf("error getting snapshots: %v", err)
-		}
+	var f []filter
+	if filtersOk {
+		f = buildVultrDataSourceFilter(filters.(*schema.Set))
+	}
 
-		for _, ssh := range snapshots {
-			sm, err := structToMap(ssh)
+	var descriptionRegex *regexp.Regexp
+	if regexOk {
+		descriptionRegex = regexp.MustCompile(regex.(string))
+	}
 
-			if err != nil {
-				return diag.FromErr(err)
-			}
+	var snapshots []govultr.Snapshot
+	err := listAll(func(options *govultr.ListOptions) (*govultr.Meta, error) {
+		page, meta, err := client.Snapshot.List(ctx, options)
+		snapshots = append(snapshots, page...)
+		return meta, err
+	})
+	if err != nil {
+		return diag.Errorf("error getting snapshots: %v", err)
+	}
 
-			if filterLoop(f, sm) {
-				snapshotList = append(snapshotList, ssh)
-			}
-		}
-		if meta.Links.Next == "" {
-			break
-		} else {
-			options.Cursor = meta.Links.Next
+	var snapshotList []govultr.Snapshot
+	for _, ssh := range snapshots {
+		if descriptionRegex != nil && !descriptionRegex.MatchString(ssh.Description) {
 			continue
 		}
-	}
 
-	if len(snapshotList) > 1 {
-		return diag.Errorf("your search returned too many results. Please refine your search to be more specific")
+		sm, err := structToMap(ssh)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if filterLoop(f, sm) {
+			snapshotList = append(snapshotList, ssh)
+		}
 	}
 
 	if len(snapshotList) < 1 {
 		return diag.Errorf("no results were found")
 	}
 
+	if len(snapshotList) > 1 {
+		if !d.Get("most_recent").(bool) {
+			return diag.Errorf("your search returned too many results. Please refine your search to be more specific or set most_recent")
+		}
+		snapshotList = []govultr.Snapshot{latestSnapshot(snapshotList)}
+	}
+
 	d.SetId(snapshotList[0].ID)
 	d.Set("date_created", snapshotList[0].DateCreated)
 	d.Set("description", snapshotList[0].Description)
@@ -97,3 +122,13 @@ func dataSourceVultrSnapshotRead(ctx context.Context, d *schema.ResourceData, me
 	d.Set("app_id", snapshotList[0].AppID)
 	return nil
 }
+
+// latestSnapshot returns the most recently created snapshot.
+func latestSnapshot(snapshots []govultr.Snapshot) govultr.Snapshot {
+	sorted := append([]govultr.Snapshot(nil), snapshots...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return createdAfter(sorted[i].DateCreated, sorted[j].DateCreated)
+	})
+
+	return sorted[0]
+}
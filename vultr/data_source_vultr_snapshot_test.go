@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vultr/govultr/v2"
 )
 
 func TestAccDataSourceVultrSnapshot(t *testing.T) {
@@ -58,3 +59,15 @@ func testAccDataSourceVultrSnapshotBase(vpsLabel, desc string) string {
 		}
 		`, vpsLabel, desc)
 }
+
+func TestLatestSnapshot(t *testing.T) {
+	snapshots := []govultr.Snapshot{
+		{ID: "old", DateCreated: "2022-08-01T02:00:00+00:00"},
+		{ID: "invalid", DateCreated: ""},
+		{ID: "new", DateCreated: "2022-08-03T02:00:00+00:00"},
+	}
+
+	if latest := latestSnapshot(snapshots); latest.ID != "new" {
+		t.Fatalf("expected latest snapshot to be new, got %s", latest.ID)
+	}
+}
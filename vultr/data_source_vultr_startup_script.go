@@ -2,6 +2,7 @@ package vultr
 
 import (
 	"context"
+	"encoding/base64"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -90,11 +91,17 @@ func dataSourceVultrStartupScriptRead(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("error retrieving script : %s", scriptList[0])
 	}
 
+	// Match vultr_startup_script, which holds the plaintext script
+	decoded, err := base64.StdEncoding.DecodeString(script.Script)
+	if err != nil {
+		return diag.Errorf("error decoding startup script (%s): %v", script.ID, err)
+	}
+
 	d.SetId(script.ID)
 	d.Set("name", script.Name)
 	d.Set("date_created", script.DateCreated)
 	d.Set("date_modified", script.DateModified)
 	d.Set("type", script.Type)
-	d.Set("script", script.Script)
+	d.Set("script", string(decoded))
 	return nil
 }
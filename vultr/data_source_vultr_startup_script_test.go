@@ -23,7 +23,7 @@ func TestAccVultrStartupScript(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "name", rName),
 					resource.TestCheckResourceAttr(name, "type", "pxe"),
-					resource.TestCheckResourceAttrSet(name, "script"),
+					resource.TestCheckResourceAttrPair(name, "script", "vultr_startup_script.foo", "script"),
 					resource.TestCheckResourceAttrSet(name, "date_created"),
 					resource.TestCheckResourceAttrSet(name, "date_modified"),
 				),
@@ -37,7 +37,7 @@ func testAccCheckVultrStartupScriptConfigBase(name string) string {
 		resource "vultr_startup_script" "foo" {
 			name = "%s"
 			type = "pxe"
-			script = "IyEvYmluL2Jhc2hcbmVjaG8gaGVsbG8gd29ybGQgPiAvcm9vdC9oZWxsbw=="
+			script = "#!/bin/bash\necho hello world > /root/hello"
 		}
 
 		data "vultr_startup_script" "my_script" {
@@ -0,0 +1,122 @@
+package vultr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/vultr/govultr/v2"
+)
+
+// newTestClient returns a Client that sends every API request to handler. The
+// server behind it is closed when the test finishes.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := govultr.NewClient(nil)
+	if err := client.SetBaseURL(server.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return &Client{client: client}
+}
+
+// newFakeClient returns a Client whose Kubernetes service is replaced by
+// kubernetes. Other services are left pointing at the real API. Node pool
+// creates and updates are sent as raw requests rather than through the
+// service, use newFakeNodePoolClient for those.
+func newFakeClient(kubernetes govultr.KubernetesService) *Client {
+	client := govultr.NewClient(nil)
+	client.Kubernetes = kubernetes
+	return &Client{client: client}
+}
+
+// fakeKubernetesService records node pool calls made through the govultr
+// service. Methods that are not overridden panic through the nil embedded
+// interface.
+type fakeKubernetesService struct {
+	govultr.KubernetesService
+
+	err error
+
+	deleted []string
+}
+
+func (f *fakeKubernetesService) GetNodePool(ctx context.Context, vkeID, nodePoolID string) (*govultr.NodePool, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &govultr.NodePool{ID: nodePoolID}, nil
+}
+
+func (f *fakeKubernetesService) DeleteNodePool(ctx context.Context, vkeID, nodePoolID string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.deleted = append(f.deleted, nodePoolID)
+	return nil
+}
+
+// fakeNodePoolAPI records the node pool requests of cluster "cluster" sent
+// to a mock VKE API. Requests are recorded as their decoded JSON so tests can
+// tell a field sent as zero from one that was left out.
+type fakeNodePoolAPI struct {
+	mu sync.Mutex
+
+	// err is returned with a 404 status for every request when set
+	err string
+
+	created []map[string]interface{}
+	updated map[string]map[string]interface{}
+	deleted []string
+}
+
+// newFakeNodePoolClient returns a Client whose requests are served by api.
+func newFakeNodePoolClient(t *testing.T, api *fakeNodePoolAPI) *Client {
+	const poolsPath = "/v2/kubernetes/clusters/cluster/node-pools"
+
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		if api.err != "" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, api.err)
+			return
+		}
+
+		var req map[string]interface{}
+		if r.Method != http.MethodDelete {
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("unexpected error decoding request: %v", err)
+				return
+			}
+		}
+
+		nodePoolID := strings.TrimPrefix(r.URL.Path, poolsPath+"/")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == poolsPath:
+			api.created = append(api.created, req)
+			fmt.Fprintf(w, `{"node_pool":{"id":"created-pool","label":%q,"plan":%q,"node_quantity":%v}}`, req["label"], req["plan"], req["node_quantity"])
+		case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, poolsPath+"/"):
+			if api.updated == nil {
+				api.updated = map[string]map[string]interface{}{}
+			}
+			api.updated[nodePoolID] = req
+			fmt.Fprintf(w, `{"node_pool":{"id":%q,"node_quantity":%v}}`, nodePoolID, req["node_quantity"])
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, poolsPath+"/"):
+			api.deleted = append(api.deleted, nodePoolID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+		}
+	})
+}
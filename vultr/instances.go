@@ -3,13 +3,32 @@ package vultr
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vultr/govultr/v2"
 )
 
 func getVPCs(client *govultr.Client, instanceID string) ([]string, error) {
-	options := &govultr.ListOptions{}
+	vpcInfo, err := getVPCInfo(client, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
 	var vpcs []string
+	for _, v := range vpcInfo {
+		vpcs = append(vpcs, v.ID)
+	}
+	return vpcs, nil
+}
+
+// getVPCInfo returns the VPCs attached to an instance along with the address
+// of the instance on each of them.
+func getVPCInfo(client *govultr.Client, instanceID string) ([]govultr.VPCInfo, error) {
+	options := &govultr.ListOptions{}
+	var vpcs []govultr.VPCInfo
 	for {
 		vpcInfo, meta, err := client.Instance.ListVPCInfo(context.Background(), instanceID, options)
 		if err != nil {
@@ -20,9 +39,7 @@ func getVPCs(client *govultr.Client, instanceID string) ([]string, error) {
 			break
 		}
 
-		for _, v := range vpcInfo {
-			vpcs = append(vpcs, v.ID)
-		}
+		vpcs = append(vpcs, vpcInfo...)
 
 		if meta.Links.Next == "" {
 			break
@@ -31,3 +48,107 @@ func getVPCs(client *govultr.Client, instanceID string) ([]string, error) {
 	}
 	return vpcs, nil
 }
+
+// networkIDChanges returns the VPC or legacy private network IDs to attach
+// and detach to go from the old to the new set of IDs.
+func networkIDChanges(oldIDs, newIDs *schema.Set) (attach, detach []string) {
+	var oldSlice, newSlice []string
+	for _, v := range oldIDs.List() {
+		oldSlice = append(oldSlice, v.(string))
+	}
+	for _, v := range newIDs.List() {
+		newSlice = append(newSlice, v.(string))
+	}
+
+	return diffSlice(oldSlice, newSlice), diffSlice(newSlice, oldSlice)
+}
+
+// instanceCreateReq adds the marketplace app variables, which govultr does
+// not send, to an instance create request.
+type instanceCreateReq struct {
+	*govultr.InstanceCreateReq
+	AppVariables map[string]string `json:"app_variables,omitempty"`
+}
+
+type marketplaceAppVariable struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"`
+}
+
+func createInstance(ctx context.Context, client *govultr.Client, instanceReq *govultr.InstanceCreateReq, appVariables map[string]string) (*govultr.Instance, error) {
+	req, err := client.NewRequest(ctx, http.MethodPost, "/v2/instances", &instanceCreateReq{instanceReq, appVariables})
+	if err != nil {
+		return nil, err
+	}
+
+	instance := new(struct {
+		Instance *govultr.Instance `json:"instance"`
+	})
+	if err := client.DoWithContext(ctx, req, instance); err != nil {
+		return nil, err
+	}
+
+	return instance.Instance, nil
+}
+
+func getMarketplaceAppVariables(ctx context.Context, client *govultr.Client, imageID string) ([]marketplaceAppVariable, error) {
+	req, err := client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("/v2/marketplace/apps/%s/variables", imageID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	variables := new(struct {
+		Variables []marketplaceAppVariable `json:"variables"`
+	})
+	if err := client.DoWithContext(ctx, req, variables); err != nil {
+		return nil, err
+	}
+
+	return variables.Variables, nil
+}
+
+// missingAppVariables returns the names of the required variables that have
+// no value in appVariables.
+func missingAppVariables(variables []marketplaceAppVariable, appVariables map[string]string) []string {
+	var missing []string
+	for _, v := range variables {
+		if _, ok := appVariables[v.Name]; v.Required && !ok {
+			missing = append(missing, v.Name)
+		}
+	}
+	return missing
+}
+
+// waitForInstanceIPv6 waits for an instance that just had IPv6 enabled to be
+// assigned its IPv6 address.
+func waitForInstanceIPv6(ctx context.Context, client *govultr.Client, instanceID string, timeout time.Duration) error {
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		instance, err := client.Instance.Get(ctx, instanceID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if instance.V6MainIP == "" {
+			return resource.RetryableError(fmt.Errorf("instance %s has no IPv6 address yet", instanceID))
+		}
+		return nil
+	})
+}
+
+// waitForInstanceISO waits for an ISO attach or detach to finish, which is
+// when the instance reports isoID as mounted and is no longer (un)mounting.
+// An empty isoID waits for the ISO to be detached.
+func waitForInstanceISO(ctx context.Context, client *govultr.Client, instanceID, isoID string, timeout time.Duration) error {
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		iso, err := client.Instance.ISOStatus(ctx, instanceID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if iso.IsoID != isoID || iso.State == "isomounting" || iso.State == "isounmounting" {
+			return resource.RetryableError(fmt.Errorf("instance %s has iso %q in state %s", instanceID, iso.IsoID, iso.State))
+		}
+		return nil
+	})
+}
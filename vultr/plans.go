@@ -0,0 +1,42 @@
+package vultr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vultr/govultr/v2"
+)
+
+// getBareMetalPlan looks up a bare metal plan by its ID, e.g.
+// "vbm-4c-32gb".
+func getBareMetalPlan(ctx context.Context, client *govultr.Client, planID string) (*govultr.BareMetalPlan, error) {
+	var plan *govultr.BareMetalPlan
+	err := listAll(func(options *govultr.ListOptions) (*govultr.Meta, error) {
+		plans, meta, err := client.Plan.ListBareMetal(ctx, options)
+		for i := range plans {
+			if plans[i].ID == planID {
+				plan = &plans[i]
+			}
+		}
+		return meta, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error getting bare metal plans: %v", err)
+	}
+
+	if plan == nil {
+		return nil, fmt.Errorf("bare metal plan %s not found", planID)
+	}
+
+	return plan, nil
+}
+
+// planHasLocation reports whether a plan is offered in a region.
+func planHasLocation(locations []string, regionID string) bool {
+	for _, l := range locations {
+		if l == regionID {
+			return true
+		}
+	}
+	return false
+}
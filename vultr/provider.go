@@ -1,7 +1,15 @@
 package vultr
 
 import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider ...
@@ -10,19 +18,43 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"api_key": {
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("VULTR_API_KEY", nil),
-				Description: "The API Key that allows interaction with the API",
+				Optional:    true,
+				Description: "The API Key that allows interaction with the API. Falls back to api_key_file and then the VULTR_API_KEY environment variable",
 			},
-			"rate_limit": {
-				Type:        schema.TypeInt,
+			"api_key_file": {
+				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Allows users to set the speed of API calls to work with the Vultr Rate Limit",
+				DefaultFunc: schema.EnvDefaultFunc("VULTR_API_KEY_FILE", nil),
+				Description: "Path to a file containing the API Key, used when api_key is not set",
+			},
+			"rate_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Allows users to set the speed of API calls to work with the Vultr Rate Limit",
+			},
+			"api_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VULTR_API_URL", nil),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "Allows users to override the Vultr API endpoint, e.g. to target a mock server",
 			},
 			"retry_limit": {
-				Type:        schema.TypeInt,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Allows users to set the maximum number of retries allowed for a failed API call.",
+			},
+			"debug": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Log the method, URL and response status of every Vultr API call",
+			},
+			"default_tag": {
+				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Allows users to set the maximum number of retries allowed for a failed API call.",
+				Description: "A tag applied to instances, bare metal servers and VKE node pools that do not set their own tag. Instances and bare metal servers that do not set tags also get it as their only tag.",
 			},
 		},
 
@@ -31,6 +63,7 @@ func Provider() *schema.Provider {
 			"vultr_application":            dataSourceVultrApplication(),
 			"vultr_backup":                 dataSourceVultrBackup(),
 			"vultr_bare_metal_plan":        dataSourceVultrBareMetalPlan(),
+			"vultr_bare_metal_plans":       dataSourceVultrBareMetalPlans(),
 			"vultr_bare_metal_server":      dataSourceVultrBareMetalServer(),
 			"vultr_block_storage":          dataSourceVultrBlockStorage(),
 			"vultr_dns_domain":             dataSourceVultrDNSDomain(),
@@ -38,6 +71,8 @@ func Provider() *schema.Provider {
 			"vultr_iso_private":            dataSourceVultrIsoPrivate(),
 			"vultr_iso_public":             dataSourceVultrIsoPublic(),
 			"vultr_kubernetes":             dataSourceVultrKubernetes(),
+			"vultr_kubernetes_upgrades":    dataSourceVultrKubernetesUpgrades(),
+			"vultr_kubernetes_versions":    dataSourceVultrKubernetesVersions(),
 			"vultr_load_balancer":          dataSourceVultrLoadBalancer(),
 			"vultr_private_network":        dataSourceVultrPrivateNetwork(),
 			"vultr_object_storage":         dataSourceVultrObjectStorage(),
@@ -59,41 +94,84 @@ func Provider() *schema.Provider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"vultr_bare_metal_server":     resourceVultrBareMetalServer(),
-			"vultr_block_storage":         resourceVultrBlockStorage(),
-			"vultr_dns_domain":            resourceVultrDNSDomain(),
-			"vultr_dns_record":            resourceVultrDNSRecord(),
-			"vultr_firewall_group":        resourceVultrFirewallGroup(),
-			"vultr_firewall_rule":         resourceVultrFirewallRule(),
-			"vultr_iso_private":           resourceVultrIsoPrivate(),
-			"vultr_kubernetes":            resourceVultrKubernetes(),
-			"vultr_kubernetes_node_pools": resourceVultrKubernetesNodePools(),
-			"vultr_load_balancer":         resourceVultrLoadBalancer(),
-			"vultr_private_network":       resourceVultrPrivateNetwork(),
-			"vultr_object_storage":        resourceVultrObjectStorage(),
-			"vultr_reserved_ip":           resourceVultrReservedIP(),
-			"vultr_reverse_ipv4":          resourceVultrReverseIPV4(),
-			"vultr_reverse_ipv6":          resourceVultrReverseIPV6(),
-			"vultr_snapshot":              resourceVultrSnapshot(),
-			"vultr_snapshot_from_url":     resourceVultrSnapshotFromURL(),
-			"vultr_instance":              resourceVultrInstance(),
-			"vultr_instance_ipv4":         resourceVultrInstanceIPV4(),
-			"vultr_ssh_key":               resourceVultrSSHKey(),
-			"vultr_startup_script":        resourceVultrStartupScript(),
-			"vultr_user":                  resourceVultrUsers(),
-			"vultr_vpc":                   resourceVultrVPC(),
+			"vultr_bare_metal_server":        resourceVultrBareMetalServer(),
+			"vultr_block_storage":            resourceVultrBlockStorage(),
+			"vultr_block_storage_attachment": resourceVultrBlockStorageAttachment(),
+			"vultr_container_registry":       resourceVultrContainerRegistry(),
+			"vultr_dns_domain":               resourceVultrDNSDomain(),
+			"vultr_dns_record":               resourceVultrDNSRecord(),
+			"vultr_firewall_group":           resourceVultrFirewallGroup(),
+			"vultr_firewall_rule":            resourceVultrFirewallRule(),
+			"vultr_iso_private":              resourceVultrIsoPrivate(),
+			"vultr_kubernetes":               resourceVultrKubernetes(),
+			"vultr_kubernetes_node_pools":    resourceVultrKubernetesNodePools(),
+			"vultr_load_balancer":            resourceVultrLoadBalancer(),
+			"vultr_private_network":          resourceVultrPrivateNetwork(),
+			"vultr_object_storage":           resourceVultrObjectStorage(),
+			"vultr_reserved_ip":              resourceVultrReservedIP(),
+			"vultr_reverse_ipv4":             resourceVultrReverseIPV4(),
+			"vultr_reverse_ipv6":             resourceVultrReverseIPV6(),
+			"vultr_snapshot":                 resourceVultrSnapshot(),
+			"vultr_snapshot_from_url":        resourceVultrSnapshotFromURL(),
+			"vultr_instance":                 resourceVultrInstance(),
+			"vultr_instance_ipv4":            resourceVultrInstanceIPV4(),
+			"vultr_ssh_key":                  resourceVultrSSHKey(),
+			"vultr_startup_script":           resourceVultrStartupScript(),
+			"vultr_user":                     resourceVultrUsers(),
+			"vultr_vpc":                      resourceVultrVPC(),
+			"vultr_vpc2":                     resourceVultrVPC2(),
 		},
 
-		ConfigureFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	apiKey, err := resolveAPIKey(d.Get("api_key").(string), d.Get("api_key_file").(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
 	config := Config{
-		APIKey:     d.Get("api_key").(string),
+		APIKey:     apiKey,
 		RateLimit:  d.Get("rate_limit").(int),
 		RetryLimit: d.Get("retry_limit").(int),
+		APIURL:     d.Get("api_url").(string),
+		Debug:      d.Get("debug").(bool),
+		DefaultTag: d.Get("default_tag").(string),
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	return client, nil
+}
+
+// resolveAPIKey returns the API key from, in order, the api_key argument, the
+// file named by api_key_file, or the VULTR_API_KEY environment variable.
+func resolveAPIKey(apiKey, apiKeyFile string) (string, error) {
+	if apiKey != "" {
+		return apiKey, nil
+	}
+
+	if apiKeyFile != "" {
+		contents, err := ioutil.ReadFile(apiKeyFile)
+		if err != nil {
+			return "", fmt.Errorf("error reading api_key_file: %v", err)
+		}
+
+		key := strings.TrimSpace(string(contents))
+		if key == "" {
+			return "", fmt.Errorf("api_key_file %s is empty", apiKeyFile)
+		}
+		return key, nil
+	}
+
+	if key := os.Getenv("VULTR_API_KEY"); key != "" {
+		return key, nil
 	}
 
-	return config.Client()
+	return "", fmt.Errorf("an API key is required: set api_key, api_key_file, or the VULTR_API_KEY environment variable")
 }
@@ -2,7 +2,9 @@ package vultr
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -34,6 +36,48 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestResolveAPIKey(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+	if err := ioutil.WriteFile(keyFile, []byte("  file-key\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := filepath.Join(dir, "empty")
+	if err := ioutil.WriteFile(emptyFile, []byte("\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("VULTR_API_KEY", "env-key")
+
+	key, err := resolveAPIKey("explicit-key", keyFile)
+	if err != nil || key != "explicit-key" {
+		t.Errorf("expected explicit api_key to win, got %q, %v", key, err)
+	}
+
+	key, err = resolveAPIKey("", keyFile)
+	if err != nil || key != "file-key" {
+		t.Errorf("expected trimmed key from api_key_file, got %q, %v", key, err)
+	}
+
+	key, err = resolveAPIKey("", "")
+	if err != nil || key != "env-key" {
+		t.Errorf("expected key from VULTR_API_KEY, got %q, %v", key, err)
+	}
+
+	if _, err := resolveAPIKey("", emptyFile); err == nil {
+		t.Error("expected an error for an empty api_key_file")
+	}
+
+	if _, err := resolveAPIKey("", filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected an error for a missing api_key_file")
+	}
+
+	t.Setenv("VULTR_API_KEY", "")
+	if _, err := resolveAPIKey("", ""); err == nil {
+		t.Error("expected an error when no key is set")
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("VULTR_API_KEY"); v == "" {
 		t.Fatal("VULTR_API_KEY must be set for acceptance tests")
@@ -0,0 +1,47 @@
+package vultr
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vultr/govultr/v2"
+)
+
+// getRegion looks up a region by its ID, e.g. "ewr". The error for an
+// unknown region lists the valid IDs.
+func getRegion(ctx context.Context, client *govultr.Client, regionID string) (*govultr.Region, error) {
+	var ids []string
+	options := &govultr.ListOptions{}
+	for {
+		regions, meta, err := client.Region.List(ctx, options)
+		if err != nil {
+			return nil, fmt.Errorf("error getting regions: %v", err)
+		}
+
+		for i := range regions {
+			if regions[i].ID == regionID {
+				return &regions[i], nil
+			}
+			ids = append(ids, regions[i].ID)
+		}
+
+		if meta == nil || meta.Links == nil || meta.Links.Next == "" {
+			break
+		}
+		options.Cursor = meta.Links.Next
+	}
+
+	return nil, fmt.Errorf("region %s not found, valid regions are: %s", regionID, strings.Join(ids, ", "))
+}
+
+// regionHasOption reports whether a region offers an option such as
+// "ddos_protection" or "block_storage".
+func regionHasOption(region *govultr.Region, option string) bool {
+	for _, o := range region.Options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package vultr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestGetRegion(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(w, `{"regions":[{"id":"ewr","options":["ddos_protection","block_storage"]}],"meta":{"total":2,"links":{"next":"page-2","prev":""}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"regions":[{"id":"sgp","options":["block_storage"]}],"meta":{"total":2,"links":{"next":"","prev":""}}}`)
+	}).govultrClient()
+
+	region, err := getRegion(context.Background(), client, "sgp")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if region.ID != "sgp" || regionHasOption(region, "ddos_protection") || !regionHasOption(region, "block_storage") {
+		t.Errorf("unexpected region %+v", region)
+	}
+
+	if _, err := getRegion(context.Background(), client, "xyz"); err == nil {
+		t.Error("expected an error for an unknown region")
+	}
+}
@@ -24,6 +24,7 @@ func resourceVultrBareMetalServer() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceVultrBareMetalServerCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"region": {
@@ -207,7 +208,7 @@ func resourceVultrBareMetalServerCreate(ctx context.Context, d *schema.ResourceD
 		UserData:        base64.StdEncoding.EncodeToString([]byte(d.Get("user_data").(string))),
 		ActivationEmail: govultr.BoolToBoolPtr(d.Get("activation_email").(bool)),
 		Hostname:        d.Get("hostname").(string),
-		Tag:             d.Get("tag").(string),
+		Tag:             meta.(*Client).tagOrDefault(d.Get("tag").(string)),
 		ReservedIPv4:    d.Get("reserved_ipv4").(string),
 	}
 
@@ -227,6 +228,7 @@ func resourceVultrBareMetalServerCreate(ctx context.Context, d *schema.ResourceD
 			req.Tags = append(req.Tags, v.(string))
 		}
 	}
+	req.Tags = meta.(*Client).tagsOrDefault(req.Tags)
 
 	client := meta.(*Client).govultrClient()
 
@@ -238,13 +240,37 @@ func resourceVultrBareMetalServerCreate(ctx context.Context, d *schema.ResourceD
 	d.SetId(bm.ID)
 	log.Printf("[INFO] Bare Metal Server ID: %s", d.Id())
 
-	if _, err = waitForBareMetalServerActiveStatus(ctx, d, meta); err != nil {
+	if _, err = waitForBareMetalServerActiveStatus(ctx, d, d.Timeout(schema.TimeoutCreate), meta); err != nil {
 		return diag.Errorf("error while waiting for bare metal server (%s) to be in active state: %s", d.Id(), err)
 	}
 
 	return resourceVultrBareMetalServerRead(ctx, d, meta)
 }
 
+// resourceVultrBareMetalServerCustomizeDiff checks that the plan is offered in
+// the region, which the API would otherwise only reject once the server is
+// being created.
+func resourceVultrBareMetalServerCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || !d.NewValueKnown("plan") || !d.NewValueKnown("region") {
+		return nil
+	}
+
+	planID, regionID := d.Get("plan").(string), d.Get("region").(string)
+	plan, err := getBareMetalPlan(ctx, meta.(*Client).govultrClient(), planID)
+	if err != nil {
+		return err
+	}
+
+	if !planHasLocation(plan.Locations, regionID) {
+		if len(plan.Locations) == 0 {
+			return fmt.Errorf("bare metal plan %s is not available in any region", planID)
+		}
+		return fmt.Errorf("bare metal plan %s is not available in region %s, it is offered in: %s", planID, regionID, strings.Join(plan.Locations, ", "))
+	}
+
+	return nil
+}
+
 func resourceVultrBareMetalServerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
@@ -272,8 +298,9 @@ func resourceVultrBareMetalServerRead(ctx context.Context, d *schema.ResourceDat
 	d.Set("gateway_v4", bms.GatewayV4)
 	d.Set("plan", bms.Plan)
 	d.Set("label", bms.Label)
-	d.Set("tag", bms.Tag)
-	d.Set("tags", bms.Tags)
+	d.Set("tag", meta.(*Client).stateTag(d.Get("tag").(string), bms.Tag))
+	_, configuredTags := tfChangeToSlices("tags", d)
+	d.Set("tags", meta.(*Client).stateTags(configuredTags, bms.Tags))
 	d.Set("mac_address", bms.MacAddress)
 	d.Set("os_id", bms.OsID)
 	d.Set("app_id", bms.AppID)
@@ -290,7 +317,7 @@ func resourceVultrBareMetalServerUpdate(ctx context.Context, d *schema.ResourceD
 
 	req := &govultr.BareMetalUpdate{
 		Label:      d.Get("label").(string),
-		Tag:        govultr.StringToStringPtr(d.Get("tag").(string)),
+		Tag:        govultr.StringToStringPtr(meta.(*Client).tagOrDefault(d.Get("tag").(string))),
 		Tags:       []string{},
 		EnableIPv6: govultr.BoolToBoolPtr(d.Get("enable_ipv6").(bool)),
 	}
@@ -313,13 +340,20 @@ func resourceVultrBareMetalServerUpdate(ctx context.Context, d *schema.ResourceD
 
 	if d.HasChange("tags") {
 		_, newTags := tfChangeToSlices("tags", d)
-		req.Tags = newTags
+		req.Tags = meta.(*Client).tagsOrDefault(newTags)
 	}
 
 	if _, err := client.BareMetalServer.Update(ctx, d.Id(), req); err != nil {
 		return diag.Errorf("error updating bare metal %s : %s", d.Id(), err.Error())
 	}
 
+	// Changing the operating system or application reinstalls the server
+	if d.HasChanges("app_id", "os_id") {
+		if _, err := waitForBareMetalServerActiveStatus(ctx, d, d.Timeout(schema.TimeoutUpdate), meta); err != nil {
+			return diag.Errorf("error while waiting for bare metal server (%s) to be in active state: %s", d.Id(), err)
+		}
+	}
+
 	return resourceVultrBareMetalServerRead(ctx, d, meta)
 }
 
@@ -352,14 +386,14 @@ func bareMetalServerOSCheck(options map[string]bool) (string, error) {
 	return result[0], nil
 }
 
-func waitForBareMetalServerActiveStatus(ctx context.Context, d *schema.ResourceData, meta interface{}) (interface{}, error) {
+func waitForBareMetalServerActiveStatus(ctx context.Context, d *schema.ResourceData, timeout time.Duration, meta interface{}) (interface{}, error) {
 	log.Printf("[INFO] Waiting for bare metal server (%s) to have status of active", d.Id())
 
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"pending"},
 		Target:     []string{"active"},
 		Refresh:    newBareMetalServerStatusStateRefresh(ctx, d, meta),
-		Timeout:    60 * time.Minute,
+		Timeout:    timeout,
 		Delay:      10 * time.Second,
 		MinTimeout: 3 * time.Second,
 
@@ -3,6 +3,7 @@ package vultr
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"testing"
 
@@ -11,6 +12,38 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
+func TestResourceVultrBareMetalServerDiffPlanRegion(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/plans-metal" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"plans_metal":[{"id":"vbm-4c-32gb","locations":["ewr","sea"]}],"meta":{"links":{"next":""}}}`)
+	})
+
+	config := func(plan, region string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"plan":   plan,
+			"region": region,
+			"os_id":  1743,
+		})
+	}
+
+	r := resourceVultrBareMetalServer()
+	if _, err := r.Diff(context.Background(), nil, config("vbm-4c-32gb", "ewr"), client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := r.Diff(context.Background(), nil, config("vbm-4c-32gb", "lax"), client)
+	if err == nil || !strings.Contains(err.Error(), "not available in region lax, it is offered in: ewr, sea") {
+		t.Fatalf("expected an error naming the regions the plan is offered in, got %v", err)
+	}
+
+	_, err = r.Diff(context.Background(), nil, config("vbm-missing", "ewr"), client)
+	if err == nil || !strings.Contains(err.Error(), "bare metal plan vbm-missing not found") {
+		t.Fatalf("expected an error for an unknown plan, got %v", err)
+	}
+}
+
 func TestAccVultrBareMetalServerBasic(t *testing.T) {
 	t.Parallel()
 	rInt := acctest.RandInt()
@@ -22,6 +22,7 @@ func resourceVultrBlockStorage() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceVultrBlockStorageCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"size_gb": {
@@ -155,17 +156,26 @@ func resourceVultrBlockStorageRead(ctx context.Context, d *schema.ResourceData,
 func resourceVultrBlockStorageUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
-	blockReq := &govultr.BlockStorageUpdate{}
-	if d.HasChange("label") {
-		blockReq.Label = d.Get("label").(string)
-	}
+	if d.HasChanges("label", "size_gb") {
+		blockReq := &govultr.BlockStorageUpdate{}
+		if d.HasChange("label") {
+			blockReq.Label = d.Get("label").(string)
+		}
 
-	if d.HasChange("size_gb") {
-		blockReq.SizeGB = d.Get("size_gb").(int)
-	}
+		if d.HasChange("size_gb") {
+			blockReq.SizeGB = d.Get("size_gb").(int)
+		}
 
-	if err := client.BlockStorage.Update(ctx, d.Id(), blockReq); err != nil {
-		return diag.Errorf("error getting block storage: %v", err)
+		if err := client.BlockStorage.Update(ctx, d.Id(), blockReq); err != nil {
+			return diag.Errorf("error getting block storage: %v", err)
+		}
+
+		// Resizing puts the block storage back into a pending state
+		if d.HasChange("size_gb") {
+			if _, err := waitForBlockAvailable(ctx, d, "active", []string{"pending", "resizing"}, "status", meta); err != nil {
+				return diag.Errorf("error while waiting for block %s to be resized: %s", d.Id(), err)
+			}
+		}
 	}
 
 	if d.HasChange("attached_to_instance") {
@@ -204,6 +214,19 @@ func resourceVultrBlockStorageUpdate(ctx context.Context, d *schema.ResourceData
 	return resourceVultrBlockStorageRead(ctx, d, meta)
 }
 
+func resourceVultrBlockStorageCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("size_gb") {
+		return nil
+	}
+
+	oldSize, newSize := d.GetChange("size_gb")
+	if newSize.(int) < oldSize.(int) {
+		return fmt.Errorf("size_gb cannot be decreased from %d to %d, block storage can only be expanded", oldSize, newSize)
+	}
+
+	return nil
+}
+
 func resourceVultrBlockStorageDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
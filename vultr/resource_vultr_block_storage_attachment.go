@@ -0,0 +1,165 @@
+package vultr
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vultr/govultr/v2"
+)
+
+// resourceVultrBlockStorageAttachment attaches a block storage volume to an
+// instance. A volume is attached to at most one instance, so the attachment
+// is identified by the block storage ID.
+func resourceVultrBlockStorageAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVultrBlockStorageAttachmentCreate,
+		ReadContext:   resourceVultrBlockStorageAttachmentRead,
+		UpdateContext: resourceVultrBlockStorageAttachmentUpdate,
+		DeleteContext: resourceVultrBlockStorageAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"block_storage_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"instance_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"live": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"mount_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+	}
+}
+
+func resourceVultrBlockStorageAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).govultrClient()
+
+	blockID := d.Get("block_storage_id").(string)
+	instanceID := d.Get("instance_id").(string)
+
+	log.Printf("[INFO] Attaching block storage (%s) to instance (%s)", blockID, instanceID)
+	attachReq := &govultr.BlockStorageAttach{
+		InstanceID: instanceID,
+		Live:       govultr.BoolToBoolPtr(d.Get("live").(bool)),
+	}
+	if err := client.BlockStorage.Attach(ctx, blockID, attachReq); err != nil {
+		return diag.Errorf("error attaching block storage (%s) to instance (%s): %v", blockID, instanceID, err)
+	}
+
+	d.SetId(blockID)
+
+	if err := waitForBlockStorageAttachment(ctx, client, blockID, instanceID, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("error while waiting for block storage (%s) to be attached: %v", blockID, err)
+	}
+
+	return resourceVultrBlockStorageAttachmentRead(ctx, d, meta)
+}
+
+func resourceVultrBlockStorageAttachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).govultrClient()
+
+	bs, err := client.BlockStorage.Get(ctx, d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "\"status\":404") {
+			log.Printf("[WARN] Removing block storage attachment (%s) because the block storage is gone", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting block storage (%s): %v", d.Id(), err)
+	}
+
+	// An imported attachment takes the instance from the API
+	instanceID := d.Get("instance_id").(string)
+	if instanceID == "" {
+		instanceID = bs.AttachedToInstance
+	}
+
+	if bs.AttachedToInstance == "" || bs.AttachedToInstance != instanceID {
+		log.Printf("[WARN] Removing block storage attachment (%s) because it is no longer attached to instance (%s)", d.Id(), instanceID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("block_storage_id", bs.ID)
+	d.Set("instance_id", bs.AttachedToInstance)
+	d.Set("mount_id", bs.MountID)
+
+	return nil
+}
+
+// resourceVultrBlockStorageAttachmentUpdate only handles live, which is used
+// the next time the volume is detached.
+func resourceVultrBlockStorageAttachmentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceVultrBlockStorageAttachmentRead(ctx, d, meta)
+}
+
+func resourceVultrBlockStorageAttachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).govultrClient()
+
+	// The instance may already be gone, which detaches the volume
+	bs, err := client.BlockStorage.Get(ctx, d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "\"status\":404") {
+			return nil
+		}
+		return diag.Errorf("error getting block storage (%s): %v", d.Id(), err)
+	}
+
+	if bs.AttachedToInstance != d.Get("instance_id").(string) {
+		return nil
+	}
+
+	log.Printf("[INFO] Detaching block storage (%s) from instance (%s)", d.Id(), bs.AttachedToInstance)
+	detachReq := &govultr.BlockStorageDetach{Live: govultr.BoolToBoolPtr(d.Get("live").(bool))}
+	if err := client.BlockStorage.Detach(ctx, d.Id(), detachReq); err != nil {
+		return diag.Errorf("error detaching block storage (%s): %v", d.Id(), err)
+	}
+
+	if err := waitForBlockStorageAttachment(ctx, client, d.Id(), "", d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.Errorf("error while waiting for block storage (%s) to be detached: %v", d.Id(), err)
+	}
+
+	return nil
+}
+
+// waitForBlockStorageAttachment polls the volume until it is attached to
+// instanceID, or detached when instanceID is empty.
+func waitForBlockStorageAttachment(ctx context.Context, client *govultr.Client, blockID, instanceID string, timeout time.Duration) error {
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		bs, err := client.BlockStorage.Get(ctx, blockID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if bs.AttachedToInstance != instanceID {
+			return resource.RetryableError(fmt.Errorf("block storage %s is attached to %q, expected %q", blockID, bs.AttachedToInstance, instanceID))
+		}
+		return nil
+	})
+}
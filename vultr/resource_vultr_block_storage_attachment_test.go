@@ -0,0 +1,108 @@
+package vultr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceVultrBlockStorageAttachment(t *testing.T) {
+	rLabel := acctest.RandomWithPrefix("tf-bs-attach")
+	name := "vultr_block_storage_attachment.foo"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckVultrBlockStorageDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVultrBlockStorageAttachmentConfig(rLabel, "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name, "block_storage_id", "vultr_block_storage.foo", "id"),
+					resource.TestCheckResourceAttrPair(name, "instance_id", "vultr_instance.first", "id"),
+					resource.TestCheckResourceAttrSet(name, "mount_id"),
+				),
+			},
+			{
+				// Moving the attachment keeps the volume
+				Config: testAccVultrBlockStorageAttachmentConfig(rLabel, "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name, "instance_id", "vultr_instance.second", "id"),
+					resource.TestCheckResourceAttrPair("vultr_block_storage.foo", "attached_to_instance", "vultr_instance.second", "id"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"live"},
+			},
+		},
+	})
+}
+
+func TestResourceVultrBlockStorageAttachmentRead(t *testing.T) {
+	attachedTo := "instance-1"
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/blocks/block" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		fmt.Fprintf(w, `{"block":{"id":"block","attached_to_instance":%q,"mount_id":"ewr-123"}}`, attachedTo)
+	})
+
+	// An import takes the instance from the API
+	d := resourceVultrBlockStorageAttachment().TestResourceData()
+	d.SetId("block")
+	if diags := resourceVultrBlockStorageAttachmentRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Get("instance_id").(string) != "instance-1" || d.Get("mount_id").(string) != "ewr-123" {
+		t.Fatalf("unexpected attachment %q with mount %q", d.Get("instance_id"), d.Get("mount_id"))
+	}
+
+	// A volume moved to another instance outside of terraform
+	attachedTo = "instance-2"
+	if diags := resourceVultrBlockStorageAttachmentRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected the attachment to be removed from state, got ID %q", d.Id())
+	}
+}
+
+func testAccVultrBlockStorageAttachmentConfig(label, instance string) string {
+	return fmt.Sprintf(`
+	resource "vultr_block_storage" "foo" {
+		region  = "ewr"
+		size_gb = 10
+		label   = "%[1]s"
+
+		lifecycle {
+			ignore_changes = [attached_to_instance]
+		}
+	}
+
+	resource "vultr_instance" "first" {
+		label  = "%[1]s-first"
+		region = "ewr"
+		plan   = "vc2-1c-1gb"
+		os_id  = 167
+	}
+
+	resource "vultr_instance" "second" {
+		label  = "%[1]s-second"
+		region = "ewr"
+		plan   = "vc2-1c-1gb"
+		os_id  = 167
+	}
+
+	resource "vultr_block_storage_attachment" "foo" {
+		block_storage_id = vultr_block_storage.foo.id
+		instance_id      = vultr_instance.%[2]s.id
+	}
+	`, label, instance)
+}
@@ -3,6 +3,7 @@ package vultr
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -74,6 +75,11 @@ func TestAccResourceVultrBlockStorage(t *testing.T) {
 					resource.TestCheckResourceAttrSet("vultr_block_storage.foo", "mount_id"),
 				),
 			},
+			{
+				// shrinking block storage is rejected at plan time
+				Config:      testAccVultrBlockStorageConfigUpdateLabel(rLabelUpdate, rServerLabel),
+				ExpectError: regexp.MustCompile(`size_gb cannot be decreased from 45 to 40`),
+			},
 			{
 				// test detach by unsetting the attached_to_instance
 				Config: testAccVultrBlockStorageConfigDetach(rLabelUpdate, rServerLabel),
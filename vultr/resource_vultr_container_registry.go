@@ -0,0 +1,154 @@
+package vultr
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vultr/govultr/v2"
+)
+
+func resourceVultrContainerRegistry() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVultrContainerRegistryCreate,
+		ReadContext:   resourceVultrContainerRegistryRead,
+		UpdateContext: resourceVultrContainerRegistryUpdate,
+		DeleteContext: resourceVultrContainerRegistryDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"plan": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"start_up", "business", "premium"}, false),
+			},
+			"public": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Computed fields
+			"urn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"storage_used_gb": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"storage_allowed_gb": {
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"docker_credentials": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"date_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVultrContainerRegistryCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).govultrClient()
+
+	req := &containerRegistryReq{
+		Name:   d.Get("name").(string),
+		Region: d.Get("region").(string),
+		Plan:   d.Get("plan").(string),
+		Public: govultr.BoolToBoolPtr(d.Get("public").(bool)),
+	}
+
+	registry, err := createContainerRegistry(ctx, client, req)
+	if err != nil {
+		return diag.Errorf("error creating container registry: %v", err)
+	}
+
+	d.SetId(registry.ID)
+	log.Printf("[INFO] Container registry ID: %s", d.Id())
+
+	return resourceVultrContainerRegistryRead(ctx, d, meta)
+}
+
+func resourceVultrContainerRegistryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).govultrClient()
+
+	registry, err := getContainerRegistry(ctx, client, d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "\"status\":404") {
+			log.Printf("[WARN] Vultr container registry (%s) not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting container registry (%s): %v", d.Id(), err)
+	}
+
+	d.Set("name", registry.Name)
+	d.Set("region", registry.Metadata.Region.Name)
+	d.Set("public", registry.Public)
+	d.Set("urn", registry.URN)
+	d.Set("storage_used_gb", registry.Storage.Used.GB)
+	d.Set("storage_allowed_gb", registry.Storage.Allowed.GB)
+	d.Set("date_created", registry.DateCreated)
+
+	// Every request mints a new credential, so only fetch one when state
+	// does not have it yet.
+	if d.Get("docker_credentials").(string) == "" {
+		credentials, err := getContainerRegistryDockerCredentials(ctx, client, d.Id())
+		if err != nil {
+			return diag.Errorf("error getting docker credentials for container registry (%s): %v", d.Id(), err)
+		}
+		d.Set("docker_credentials", credentials)
+	}
+
+	return nil
+}
+
+func resourceVultrContainerRegistryUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).govultrClient()
+
+	if d.HasChanges("plan", "public") {
+		log.Printf("[INFO] Updating container registry: %s", d.Id())
+		req := &containerRegistryReq{
+			Plan:   d.Get("plan").(string),
+			Public: govultr.BoolToBoolPtr(d.Get("public").(bool)),
+		}
+
+		if err := updateContainerRegistry(ctx, client, d.Id(), req); err != nil {
+			return diag.Errorf("error updating container registry (%s): %v", d.Id(), err)
+		}
+	}
+
+	return resourceVultrContainerRegistryRead(ctx, d, meta)
+}
+
+func resourceVultrContainerRegistryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).govultrClient()
+
+	log.Printf("[INFO] Deleting container registry: %s", d.Id())
+	if err := deleteContainerRegistry(ctx, client, d.Id()); err != nil {
+		return diag.Errorf("error destroying container registry (%s): %v", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package vultr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccVultrContainerRegistry(t *testing.T) {
+	rName := fmt.Sprintf("tfcr%s", acctest.RandString(8))
+
+	name := "vultr_container_registry.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckVultrContainerRegistryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVultrContainerRegistryConfig(rName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", rName),
+					resource.TestCheckResourceAttr(name, "region", "sjc"),
+					resource.TestCheckResourceAttr(name, "public", "false"),
+					resource.TestCheckResourceAttrSet(name, "urn"),
+					resource.TestCheckResourceAttrSet(name, "docker_credentials"),
+					resource.TestCheckResourceAttrSet(name, "date_created"),
+				),
+			},
+			{
+				Config: testAccVultrContainerRegistryConfig(rName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", rName),
+					resource.TestCheckResourceAttr(name, "public", "true"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"plan", "docker_credentials"},
+			},
+		},
+	})
+}
+
+func testAccCheckVultrContainerRegistryDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vultr_container_registry" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*Client).govultrClient()
+		if _, err := getContainerRegistry(context.Background(), client, rs.Primary.ID); err == nil {
+			return fmt.Errorf("container registry still exists: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccVultrContainerRegistryConfig(name string, public bool) string {
+	return fmt.Sprintf(`
+		resource "vultr_container_registry" "foo" {
+			name = "%s"
+			region = "sjc"
+			plan = "start_up"
+			public = %t
+		}
+	`, name, public)
+}
@@ -2,14 +2,22 @@ package vultr
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vultr/govultr/v2"
 )
 
+// dnsSecTimeout is how long to wait for DS records after enabling DNSSEC.
+const dnsSecTimeout = 2 * time.Minute
+
 func resourceVultrDNSDomain() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceVultrDNSDomainCreate,
@@ -42,6 +50,30 @@ func resourceVultrDNSDomain() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"ds_records": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key_tag": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"algorithm": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"digest_type": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"digest": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -67,6 +99,12 @@ func resourceVultrDNSDomainCreate(ctx context.Context, d *schema.ResourceData, m
 
 	d.SetId(domain.Domain)
 
+	if domainReq.DNSSec == "enabled" {
+		if err := waitForDNSSecRecords(ctx, client, d.Id()); err != nil {
+			return diag.Errorf("error while waiting for DS records of domain %s : %v", d.Id(), err)
+		}
+	}
+
 	return resourceVultrDNSDomainRead(ctx, d, meta)
 }
 
@@ -82,6 +120,22 @@ func resourceVultrDNSDomainRead(ctx context.Context, d *schema.ResourceData, met
 	d.Set("date_created", domain.DateCreated)
 	d.Set("dns_sec", domain.DNSSec)
 
+	var dsRecords []map[string]interface{}
+	if domain.DNSSec == "enabled" {
+		records, err := client.Domain.GetDNSSec(ctx, d.Id())
+		if err != nil {
+			return diag.Errorf("error getting DNSSEC records for domain %s : %v", d.Id(), err)
+		}
+
+		if dsRecords, err = parseDSRecords(records); err != nil {
+			return diag.Errorf("error parsing DNSSEC records for domain %s : %v", d.Id(), err)
+		}
+	}
+
+	if err := d.Set("ds_records", dsRecords); err != nil {
+		return diag.Errorf("error setting `ds_records`: %v", err)
+	}
+
 	return nil
 }
 
@@ -93,6 +147,12 @@ func resourceVultrDNSDomainUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("error updating domain %s: %v", d.Id(), err)
 	}
 
+	if d.HasChange("dns_sec") && d.Get("dns_sec").(string) == "enabled" {
+		if err := waitForDNSSecRecords(ctx, client, d.Id()); err != nil {
+			return diag.Errorf("error while waiting for DS records of domain %s : %v", d.Id(), err)
+		}
+	}
+
 	return resourceVultrDNSDomainRead(ctx, d, meta)
 }
 
@@ -107,3 +167,64 @@ func resourceVultrDNSDomainDelete(ctx context.Context, d *schema.ResourceData, m
 
 	return nil
 }
+
+// waitForDNSSecRecords polls until DS records are published for a domain,
+// which happens shortly after DNSSEC is enabled.
+func waitForDNSSecRecords(ctx context.Context, client *govultr.Client, domain string) error {
+	return resource.RetryContext(ctx, dnsSecTimeout, func() *resource.RetryError {
+		records, err := client.Domain.GetDNSSec(ctx, domain)
+		if err != nil {
+			return resource.RetryableError(err)
+		}
+
+		dsRecords, err := parseDSRecords(records)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if len(dsRecords) == 0 {
+			return resource.RetryableError(fmt.Errorf("DS records for domain %s are not available yet", domain))
+		}
+		return nil
+	})
+}
+
+// parseDSRecords extracts the DS records from the zone file lines returned by
+// the API, e.g. "example.com IN DS 27933 13 2 7DBE...". Other record types
+// such as DNSKEY are skipped.
+func parseDSRecords(records []string) ([]map[string]interface{}, error) {
+	var dsRecords []map[string]interface{}
+	for _, record := range records {
+		fields := strings.Fields(record)
+
+		// The owner name and an optional TTL come before the record type
+		typeIdx := -1
+		for i, field := range fields {
+			if field == "DS" {
+				typeIdx = i
+				break
+			}
+		}
+		if typeIdx == -1 || len(fields) < typeIdx+5 {
+			continue
+		}
+		fields = fields[typeIdx+1:]
+
+		var values [3]int
+		for i, field := range fields[:3] {
+			v, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid DS record %q: %v", record, err)
+			}
+			values[i] = v
+		}
+
+		dsRecords = append(dsRecords, map[string]interface{}{
+			"key_tag":     values[0],
+			"algorithm":   values[1],
+			"digest_type": values[2],
+			"digest":      strings.Join(fields[3:], ""),
+		})
+	}
+	return dsRecords, nil
+}
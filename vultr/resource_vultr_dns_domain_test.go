@@ -70,6 +70,62 @@ func TestAccVultrDNSDomainNewDomainForce(t *testing.T) {
 	})
 }
 
+func TestAccVultrDNSDomainDNSSec(t *testing.T) {
+	rString := acctest.RandString(6) + ".com"
+	name := "vultr_dns_domain.my-site"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckVultrDNSDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVultrDNSDomainBase(rString),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "dns_sec", "disabled"),
+					resource.TestCheckResourceAttr(name, "ds_records.#", "0"),
+				),
+			},
+			{
+				Config: testAccVultrDNSDomainDNSSec(rString),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "dns_sec", "enabled"),
+					resource.TestCheckResourceAttrSet(name, "ds_records.0.key_tag"),
+					resource.TestCheckResourceAttrSet(name, "ds_records.0.digest"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseDSRecords(t *testing.T) {
+	records := []string{
+		"example.com IN DNSKEY 257 3 13 kXKkvWU3vGYfTJGl3qBd4qhiWp5aRs7YtkCJxD2d+t7KXqwahww5IgJtxJT2yFItlggazyfXqJEVOmMJ3qT0tQ==",
+		"example.com IN DS 27933 13 1 2d9ac457e5c11a104e25d971d0a6254562bddde7",
+		"example.com. 3600 IN DS 27933 13 2 8858e7b0dfb881280ce2ca1e0eafcd93d5b53687c21da284d4f8799ba82208a9",
+	}
+
+	dsRecords, err := parseDSRecords(records)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(dsRecords) != 2 {
+		t.Fatalf("expected 2 DS records, got %d", len(dsRecords))
+	}
+
+	if dsRecords[1]["key_tag"] != 27933 || dsRecords[1]["algorithm"] != 13 || dsRecords[1]["digest_type"] != 2 {
+		t.Errorf("unexpected DS record %+v", dsRecords[1])
+	}
+	if dsRecords[1]["digest"] != "8858e7b0dfb881280ce2ca1e0eafcd93d5b53687c21da284d4f8799ba82208a9" {
+		t.Errorf("unexpected digest %v", dsRecords[1]["digest"])
+	}
+
+	if _, err := parseDSRecords([]string{"example.com IN DS abc 13 2 8858e7b0"}); err == nil {
+		t.Error("expected an error for an invalid key tag")
+	}
+}
+
 func testAccCheckVultrDNSDomainDestroy(s *terraform.State) error {
 	time.Sleep(1 * time.Second)
 	client := testAccProvider.Meta().(*Client).govultrClient()
@@ -104,3 +160,13 @@ func testAccVultrDNSDomainUpdate(domain string) string {
 			ip = "10.0.0.1"
 		}`, domain)
 }
+
+func testAccVultrDNSDomainDNSSec(domain string) string {
+	time.Sleep(1 * time.Second)
+	return fmt.Sprintf(`
+		resource "vultr_dns_domain" "my-site" {
+			domain = "%s"
+			ip = "10.0.0.0"
+			dns_sec = "enabled"
+		}`, domain)
+}
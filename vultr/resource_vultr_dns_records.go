@@ -2,8 +2,11 @@ package vultr
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"log"
+	"net"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -21,6 +24,7 @@ func resourceVultrDNSRecord() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVultrDNSRecordImport,
 		},
+		CustomizeDiff: resourceVultrDNSRecordCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"data": {
 				Type:     schema.TypeString,
@@ -51,6 +55,11 @@ func resourceVultrDNSRecord() *schema.Resource {
 				Optional: true,
 				Default:  3600,
 			},
+			"adopt_existing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
@@ -66,6 +75,30 @@ func resourceVultrDNSRecordCreate(ctx context.Context, d *schema.ResourceData, m
 		Priority: &p,
 	}
 
+	// Creating a domain with a default ip makes Vultr add its own records. A
+	// matching record is only adopted on request, since it may already be
+	// managed elsewhere and deleting either resource would remove it.
+	existing, err := findDNSRecord(ctx, client, d.Get("domain").(string), recordReq)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if existing != nil {
+		if !d.Get("adopt_existing").(bool) {
+			return diag.Errorf("a %s record named %q with the same data already exists on domain %s, import it with the ID %s:%s or set adopt_existing",
+				recordReq.Type, recordReq.Name, d.Get("domain").(string), d.Get("domain").(string), existing.ID)
+		}
+
+		log.Printf("[INFO] Adopting existing DNS record: %s", existing.ID)
+		d.SetId(existing.ID)
+
+		if err := client.DomainRecord.Update(ctx, d.Get("domain").(string), existing.ID, recordReq); err != nil {
+			return diag.Errorf("error updating DNS record %s : %v", existing.ID, err)
+		}
+
+		return resourceVultrDNSRecordRead(ctx, d, meta)
+	}
+
 	log.Print("[INFO] Creating DNS record")
 	record, err := client.DomainRecord.Create(ctx, d.Get("domain").(string), recordReq)
 	if err != nil {
@@ -75,6 +108,29 @@ func resourceVultrDNSRecordCreate(ctx context.Context, d *schema.ResourceData, m
 	d.SetId(record.ID)
 	return resourceVultrDNSRecordRead(ctx, d, meta)
 }
+
+// resourceVultrDNSRecordCustomizeDiff checks the record data against its type
+// so malformed records fail at plan time instead of at the API.
+func resourceVultrDNSRecordCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("data") {
+		return nil
+	}
+
+	// Records created before the check was added may lack a priority, so
+	// existing records are only checked when the checked fields change
+	if d.Id() != "" && !d.HasChange("type") && !d.HasChange("data") && !d.HasChange("priority") {
+		return nil
+	}
+
+	// 0 is a valid priority, so only the configuration tells whether it is set
+	prioritySet := true
+	if raw := d.GetRawConfig(); raw.IsKnown() && !raw.IsNull() {
+		prioritySet = !raw.GetAttr("priority").IsNull()
+	}
+
+	return validateDNSRecord(d.Get("type").(string), d.Get("data").(string), prioritySet)
+}
+
 func resourceVultrDNSRecordRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
@@ -127,12 +183,13 @@ func resourceVultrDNSRecordDelete(ctx context.Context, d *schema.ResourceData, m
 func resourceVultrDNSRecordImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := meta.(*Client).govultrClient()
 
+	// Accept the legacy comma separated format alongside domain:record_id
 	importID := d.Id()
-	commaIdx := strings.IndexByte(importID, ',')
-	if commaIdx == -1 {
-		return nil, fmt.Errorf(`invalid import format, expected "domain,resourceID"`)
+	sepIdx := strings.IndexAny(importID, ":,")
+	if sepIdx == -1 {
+		return nil, fmt.Errorf(`invalid import format, expected "domain:resourceID"`)
 	}
-	domain, recordID := importID[:commaIdx], importID[commaIdx+1:]
+	domain, recordID := importID[:sepIdx], importID[sepIdx+1:]
 
 	record, err := client.DomainRecord.Get(ctx, domain, recordID)
 	if err != nil {
@@ -141,5 +198,93 @@ func resourceVultrDNSRecordImport(ctx context.Context, d *schema.ResourceData, m
 
 	d.SetId(record.ID)
 	d.Set("domain", domain)
+	// Defaults are not applied to imported state
+	d.Set("adopt_existing", false)
 	return []*schema.ResourceData{d}, nil
 }
+
+// findDNSRecord looks for a record on the domain with the same type, name and data
+func findDNSRecord(ctx context.Context, client *govultr.Client, domain string, req *govultr.DomainRecordReq) (*govultr.DomainRecord, error) {
+	options := &govultr.ListOptions{}
+	for {
+		records, meta, err := client.DomainRecord.List(ctx, domain, options)
+		if err != nil {
+			return nil, fmt.Errorf("error getting DNS records for domain %s : %v", domain, err)
+		}
+
+		for i := range records {
+			if records[i].Type == req.Type && records[i].Name == req.Name && records[i].Data == req.Data {
+				return &records[i], nil
+			}
+		}
+
+		if meta.Links.Next == "" {
+			break
+		}
+		options.Cursor = meta.Links.Next
+	}
+
+	return nil, nil
+}
+
+// validateDNSRecord checks the data of a record for its type. The composite
+// SRV, CAA and SSHFP formats get an example in the error since they are the
+// ones most often written wrong.
+func validateDNSRecord(recordType, data string, prioritySet bool) error {
+	if (recordType == "MX" || recordType == "SRV") && !prioritySet {
+		return fmt.Errorf("priority is required for %s records", recordType)
+	}
+
+	fields := strings.Fields(data)
+	switch recordType {
+	case "A":
+		if ip := net.ParseIP(data); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("data for an A record must be an IPv4 address, got %q", data)
+		}
+	case "AAAA":
+		if ip := net.ParseIP(data); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("data for an AAAA record must be an IPv6 address, got %q", data)
+		}
+	case "CNAME", "NS", "MX":
+		if len(fields) != 1 || fields[0] != data {
+			return fmt.Errorf("data for a %s record must be a single hostname, got %q", recordType, data)
+		}
+	case "SRV":
+		if len(fields) != 3 || !isUintString(fields[0], 65535) || !isUintString(fields[1], 65535) {
+			return fmt.Errorf(`data for an SRV record must be "weight port target", e.g. "5 5060 sip.example.com", with the priority set in priority, got %q`, data)
+		}
+	case "CAA":
+		if len(fields) < 3 || !isUintString(fields[0], 255) || !isCAATag(fields[1]) || !isQuoted(strings.Join(fields[2:], " ")) {
+			return fmt.Errorf(`data for a CAA record must be "flags tag \"value\"", e.g. "0 issue \"letsencrypt.org\"", with tag one of issue, issuewild or iodef, got %q`, data)
+		}
+	case "SSHFP":
+		if len(fields) != 3 || !isUintString(fields[0], 255) || !isUintString(fields[1], 255) || !isHexString(fields[2]) {
+			return fmt.Errorf(`data for an SSHFP record must be "algorithm type fingerprint", e.g. "4 2 123456789abcdef...", got %q`, data)
+		}
+	}
+
+	return nil
+}
+
+// isUintString reports whether s is a whole number between 0 and max.
+func isUintString(s string, max uint64) bool {
+	n, err := strconv.ParseUint(s, 10, 64)
+	return err == nil && n <= max
+}
+
+func isCAATag(tag string) bool {
+	switch strings.ToLower(tag) {
+	case "issue", "issuewild", "iodef":
+		return true
+	}
+	return false
+}
+
+func isQuoted(s string) bool {
+	return len(s) >= 2 && strings.HasPrefix(s, `"`) && strings.HasSuffix(s, `"`)
+}
+
+func isHexString(s string) bool {
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -3,12 +3,15 @@ package vultr
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -38,6 +41,29 @@ func TestAccVultrDNSRecordBasic(t *testing.T) {
 	})
 }
 
+func TestAccVultrDNSRecordAdoptDefault(t *testing.T) {
+
+	rString := acctest.RandString(6) + ".com"
+	name := "vultr_dns_record.default"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckVultrDNSDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVultrDNSDomainBase(rString) + testAccVultrDNSRecordDefault(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVultrDomainRecordExists,
+					testAccCheckVultrDomainRecordCount(rString, "A", "", 1),
+					resource.TestCheckResourceAttr(name, "data", "10.0.0.0"),
+					resource.TestCheckResourceAttr(name, "ttl", "300"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccVultrDNSRecordImportBasic(t *testing.T) {
 	resourceName := "vultr_dns_record.example"
 	rString := acctest.RandString(6) + ".com"
@@ -55,7 +81,7 @@ func TestAccVultrDNSRecordImportBasic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 				// Requires passing both the ID and domain
-				ImportStateIdPrefix: fmt.Sprintf("%s,", rString),
+				ImportStateIdPrefix: fmt.Sprintf("%s:", rString),
 			},
 			// Test importing non-existent resource provides expected error.
 			{
@@ -69,6 +95,119 @@ func TestAccVultrDNSRecordImportBasic(t *testing.T) {
 	})
 }
 
+func TestResourceVultrDNSRecordCreateExisting(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/domains/example.com/records" && r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"records":[{"id":"record","type":"A","name":"","data":"192.0.2.1","ttl":300}],"meta":{"links":{"next":""}}}`)
+		case r.URL.Path == "/v2/domains/example.com/records/record" && r.Method == http.MethodPatch:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/v2/domains/example.com/records/record" && r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"record":{"id":"record","type":"A","name":"","data":"192.0.2.1","ttl":3600}}`)
+		default:
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+		}
+	})
+
+	newRecord := func(adopt bool) *schema.ResourceData {
+		d := resourceVultrDNSRecord().TestResourceData()
+		d.Set("domain", "example.com")
+		d.Set("type", "A")
+		d.Set("name", "")
+		d.Set("data", "192.0.2.1")
+		d.Set("adopt_existing", adopt)
+		return d
+	}
+
+	// The record may be managed elsewhere, so it is not taken over by default
+	d := newRecord(false)
+	diags := resourceVultrDNSRecordCreate(context.Background(), d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "import it with the ID example.com:record") {
+		t.Fatalf("expected an already exists error, got %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected no ID, got %q", d.Id())
+	}
+
+	d = newRecord(true)
+	if diags := resourceVultrDNSRecordCreate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "record" {
+		t.Fatalf("expected the existing record to be adopted, got %q", d.Id())
+	}
+}
+
+func TestResourceVultrDNSRecordCustomizeDiffExisting(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "record",
+		Attributes: map[string]string{
+			"id":     "record",
+			"domain": "example.com",
+			"type":   "MX",
+			"name":   "",
+			"data":   "10 mail.example.com",
+			"ttl":    "3600",
+		},
+	}
+	config := func(data string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"domain": "example.com",
+			"type":   "MX",
+			"name":   "",
+			"data":   data,
+			"ttl":    300,
+		})
+	}
+
+	r := resourceVultrDNSRecord()
+	if _, err := r.Diff(context.Background(), state, config("10 mail.example.com"), nil); err != nil {
+		t.Fatalf("unexpected error for an unchanged existing record: %v", err)
+	}
+
+	_, err := r.Diff(context.Background(), state, config("20 mail.example.com"), nil)
+	if err == nil || !strings.Contains(err.Error(), "single hostname") {
+		t.Fatalf("expected changed data to be checked, got %v", err)
+	}
+}
+
+func TestValidateDNSRecord(t *testing.T) {
+	tests := []struct {
+		recordType  string
+		data        string
+		prioritySet bool
+		valid       bool
+	}{
+		{"A", "192.0.2.1", false, true},
+		{"A", "2001:db8::1", false, false},
+		{"AAAA", "2001:db8::1", false, true},
+		{"AAAA", "192.0.2.1", false, false},
+		{"CNAME", "www.example.com", false, true},
+		{"CNAME", "www.example.com other", false, false},
+		{"MX", "mail.example.com", true, true},
+		{"MX", "mail.example.com", false, false},
+		{"SRV", "5 5060 sip.example.com", true, true},
+		{"SRV", "10 5 5060 sip.example.com", true, false},
+		{"SRV", "5 5060 sip.example.com", false, false},
+		{"CAA", `0 issue "letsencrypt.org"`, false, true},
+		{"CAA", "0 issue letsencrypt.org", false, false},
+		{"CAA", `0 policy "letsencrypt.org"`, false, false},
+		{"SSHFP", "4 2 0123456789abcdef", false, true},
+		{"SSHFP", "4 2 not-hex", false, false},
+		{"TXT", "v=spf1 include:example.com ~all", false, true},
+	}
+
+	for _, tt := range tests {
+		err := validateDNSRecord(tt.recordType, tt.data, tt.prioritySet)
+		if tt.valid && err != nil {
+			t.Errorf("expected %s record %q to be valid, got %v", tt.recordType, tt.data, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("expected %s record %q to be rejected", tt.recordType, tt.data)
+		}
+	}
+}
+
 func testAccCheckVultrDomainRecordExists(s *terraform.State) error {
 	client := testAccProvider.Meta().(*Client).govultrClient()
 
@@ -87,6 +226,43 @@ func testAccCheckVultrDomainRecordExists(s *terraform.State) error {
 	return nil
 }
 
+func testAccCheckVultrDomainRecordCount(domain, recordType, name string, expected int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Client).govultrClient()
+
+		records, _, err := client.DomainRecord.List(context.Background(), domain, nil)
+		if err != nil {
+			return fmt.Errorf("error getting dns records for domain %s : %v", domain, err)
+		}
+
+		count := 0
+		for _, r := range records {
+			if r.Type == recordType && r.Name == name {
+				count++
+			}
+		}
+
+		if count != expected {
+			return fmt.Errorf("expected %d %s records named %q, found %d", expected, recordType, name, count)
+		}
+
+		return nil
+	}
+}
+
+func testAccVultrDNSRecordDefault() string {
+	time.Sleep(1 * time.Second)
+	return `
+		resource "vultr_dns_record" "default" {
+			data = "10.0.0.0"
+			domain = "${vultr_dns_domain.my-site.id}"
+			name = ""
+			type = "A"
+			ttl = "300"
+			adopt_existing = true
+		}`
+}
+
 func testAccVultrDNSRecordBase(name string) string {
 	time.Sleep(1 * time.Second)
 	return fmt.Sprintf(`
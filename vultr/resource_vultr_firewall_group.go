@@ -2,6 +2,7 @@ package vultr
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strings"
 
@@ -17,7 +18,7 @@ func resourceVultrFirewallGroup() *schema.Resource {
 		UpdateContext: resourceVultrFirewallGroupUpdate,
 		DeleteContext: resourceVultrFirewallGroupDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceVultrFirewallGroupImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"description": {
@@ -110,3 +111,40 @@ func resourceVultrFirewallGroupDelete(ctx context.Context, d *schema.ResourceDat
 	}
 	return nil
 }
+
+// resourceVultrFirewallGroupImport imports the group and logs the import
+// blocks for its rules, which are separate vultr_firewall_rule resources and
+// would otherwise be left out of state.
+func resourceVultrFirewallGroupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).govultrClient()
+
+	var rules []govultr.FirewallRule
+	options := &govultr.ListOptions{}
+	for {
+		page, meta, err := client.FirewallRule.List(ctx, d.Id(), options)
+		if err != nil {
+			return nil, fmt.Errorf("error getting rules for firewall group %s : %v", d.Id(), err)
+		}
+		rules = append(rules, page...)
+
+		if meta == nil || meta.Links == nil || meta.Links.Next == "" {
+			break
+		}
+		options.Cursor = meta.Links.Next
+	}
+
+	if len(rules) != 0 {
+		log.Printf("[INFO] Firewall group (%s) has %d rules, import them with:\n%s", d.Id(), len(rules), firewallRuleImportBlocks(d.Id(), rules))
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// firewallRuleImportBlocks returns an import block for every rule of the group.
+func firewallRuleImportBlocks(groupID string, rules []govultr.FirewallRule) string {
+	var b strings.Builder
+	for _, rule := range rules {
+		fmt.Fprintf(&b, "import {\n  to = vultr_firewall_rule.rule_%d\n  id = \"%s:%d\"\n}\n", rule.ID, groupID, rule.ID)
+	}
+	return b.String()
+}
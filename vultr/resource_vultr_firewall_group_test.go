@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vultr/govultr/v2"
 )
 
 func TestAccVultrFirewallGroupBasic(t *testing.T) {
@@ -97,3 +98,20 @@ func testAccVultrFirewallGroupUpdate(description string) string {
 		description = "%s"
 	}`, description)
 }
+
+func TestFirewallRuleImportBlocks(t *testing.T) {
+	rules := []govultr.FirewallRule{{ID: 1}, {ID: 3}}
+
+	expected := `import {
+  to = vultr_firewall_rule.rule_1
+  id = "group:1"
+}
+import {
+  to = vultr_firewall_rule.rule_3
+  id = "group:3"
+}
+`
+	if got := firewallRuleImportBlocks("group", rules); got != expected {
+		t.Errorf("unexpected import blocks:\n%s", got)
+	}
+}
@@ -21,6 +21,7 @@ func resourceVultrFirewallRule() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVultrFirewallRuleImport,
 		},
+		CustomizeDiff: resourceVultrFirewallRuleCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"firewall_group_id": {
 				Type:     schema.TypeString,
@@ -141,16 +142,26 @@ func resourceVultrFirewallRuleDelete(ctx context.Context, d *schema.ResourceData
 	return nil
 }
 
+func resourceVultrFirewallRuleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	protocol := d.Get("protocol").(string)
+	if protocol != "tcp" && protocol != "udp" && d.Get("port").(string) != "" {
+		return fmt.Errorf("port cannot be set for protocol %q, it is only supported for tcp and udp", protocol)
+	}
+
+	return nil
+}
+
 func resourceVultrFirewallRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := meta.(*Client).govultrClient()
 
+	// Accept the legacy comma separated format alongside group_id:rule_id
 	importID := d.Id()
-	commaIdx := strings.IndexByte(importID, ',')
+	sepIdx := strings.IndexAny(importID, ":,")
 
-	if commaIdx == -1 {
-		return nil, fmt.Errorf(`invalid import format, expected "firewallGroupID,firewallRuleID"`)
+	if sepIdx == -1 {
+		return nil, fmt.Errorf(`invalid import format, expected "firewallGroupID:firewallRuleID"`)
 	}
-	fwGroup, ruleID := importID[:commaIdx], importID[commaIdx+1:]
+	fwGroup, ruleID := importID[:sepIdx], importID[sepIdx+1:]
 
 	rule, _ := strconv.Atoi(ruleID)
 	fw, err := client.FirewallRule.Get(ctx, fwGroup, rule)
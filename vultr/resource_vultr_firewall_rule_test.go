@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -52,6 +53,21 @@ func TestAccVultrFirewallRuleIcmp(t *testing.T) {
 	})
 }
 
+func TestAccVultrFirewallRuleIcmpWithPort(t *testing.T) {
+
+	rString := acctest.RandString(13)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccVultrFirewallRuleIcmpWithPort(rString),
+				ExpectError: regexp.MustCompile(`port cannot be set for protocol "icmp"`),
+			},
+		},
+	})
+}
+
 func TestAccVultrFirewallRuleUpdate(t *testing.T) {
 	rString := acctest.RandString(13)
 
@@ -172,6 +188,22 @@ func testAccVultrFirewallRuleIcmp(desc string) string {
 		}`, desc)
 }
 
+func testAccVultrFirewallRuleIcmpWithPort(desc string) string {
+	return fmt.Sprintf(`
+		resource "vultr_firewall_group" "fwg" {
+			description = "%s"
+		}
+
+		resource "vultr_firewall_rule" "icmp" {
+			firewall_group_id = "${vultr_firewall_group.fwg.id}"
+			ip_type = "v4"
+			protocol = "icmp"
+			subnet = "0.0.0.0"
+			subnet_size = 0
+			port = "22"
+		}`, desc)
+}
+
 func testAccVultrFirewallRuleUpdate(desc string) string {
 	return fmt.Sprintf(`
 		resource "vultr_firewall_group" "fwg" {
@@ -200,6 +232,6 @@ func testFirewallImportID(g, r string) resource.ImportStateIdFunc {
 			return "", fmt.Errorf("not found: %s", r)
 		}
 
-		return fmt.Sprintf("%s,%s", rs.Primary.Attributes["id"], rs2.Primary.Attributes["id"]), nil
+		return fmt.Sprintf("%s:%s", rs.Primary.Attributes["id"], rs2.Primary.Attributes["id"]), nil
 	}
 }
@@ -5,9 +5,13 @@ import (
 	"encoding/base64"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,6 +19,10 @@ import (
 	"github.com/vultr/govultr/v2"
 )
 
+// instanceNotFoundTimeout is how long a newly created instance may be missing
+// from the API before reading it fails.
+const instanceNotFoundTimeout = time.Minute
+
 func resourceVultrInstance() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceVultrInstanceCreate,
@@ -24,6 +32,7 @@ func resourceVultrInstance() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceVultrInstanceCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			//Required
@@ -54,6 +63,13 @@ func resourceVultrInstance() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"app_variables": {
+				Type:      schema.TypeMap,
+				Optional:  true,
+				ForceNew:  true,
+				Sensitive: true,
+				Elem:      &schema.Schema{Type: schema.TypeString},
+			},
 			"os_id": {
 				Type:     schema.TypeInt,
 				Computed: true,
@@ -100,11 +116,35 @@ func resourceVultrInstance() *schema.Resource {
 				ForceNew: true,
 				Optional: true,
 			},
+			"resize_disk": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"recreate_for_incompatible_plan": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// restore_snapshot_id is restored once the instance is created
+			// and again whenever it changes. It is kept as configured since
+			// the API does not report which snapshot an instance was restored
+			// from.
+			"restore_snapshot_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"user_data": {
 				Type:     schema.TypeString,
 				Computed: true,
 				Optional: true,
 				ForceNew: true,
+				// Templated user data often differs from state only by a
+				// trailing newline or by being base64 encoded, which would
+				// otherwise force a new instance.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return userDataEquivalent(old, new)
+				},
 			},
 			"activation_email": {
 				Type:     schema.TypeBool,
@@ -141,7 +181,6 @@ func resourceVultrInstance() *schema.Resource {
 			},
 			"firewall_group_id": {
 				Type:     schema.TypeString,
-				Computed: true,
 				Optional: true,
 			},
 			"backups": {
@@ -176,6 +215,10 @@ func resourceVultrInstance() *schema.Resource {
 							Optional: true,
 							Computed: true,
 						},
+						"next_scheduled_time_utc": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 					},
 				},
 			},
@@ -225,6 +268,11 @@ func resourceVultrInstance() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"desired_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"running", "stopped"}, false),
+			},
 			"power_status": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -233,6 +281,10 @@ func resourceVultrInstance() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"iso_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"v6_network": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -249,6 +301,11 @@ func resourceVultrInstance() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"vpc_internal_ips": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"kvm": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -294,11 +351,11 @@ func resourceVultrInstanceCreate(ctx context.Context, d *schema.ResourceData, me
 		EnableIPv6:      govultr.BoolToBoolPtr(d.Get("enable_ipv6").(bool)),
 		Label:           d.Get("label").(string),
 		Backups:         backups,
-		UserData:        base64.StdEncoding.EncodeToString([]byte(d.Get("user_data").(string))),
+		UserData:        encodeUserData(d.Get("user_data").(string)),
 		ActivationEmail: govultr.BoolToBoolPtr(d.Get("activation_email").(bool)),
 		DDOSProtection:  govultr.BoolToBoolPtr(d.Get("ddos_protection").(bool)),
 		Hostname:        d.Get("hostname").(string),
-		Tag:             d.Get("tag").(string),
+		Tag:             meta.(*Client).tagOrDefault(d.Get("tag").(string)),
 		FirewallGroupID: d.Get("firewall_group_id").(string),
 		ScriptID:        d.Get("script_id").(string),
 		ReservedIPv4:    d.Get("reserved_ip_id").(string),
@@ -333,11 +390,14 @@ func resourceVultrInstanceCreate(ctx context.Context, d *schema.ResourceData, me
 			req.Tags = append(req.Tags, v.(string))
 		}
 	}
+	req.Tags = meta.(*Client).tagsOrDefault(req.Tags)
 
 	if len(d.Get("private_network_ids").(*schema.Set).List()) != 0 && len(d.Get("vpc_ids").(*schema.Set).List()) != 0 {
 		return diag.Errorf("private_network_ids cannot be used along with vpc_ids. Use only vpc_ids instead.")
 	}
 
+	// attach_private_network is deprecated on create, the VPC field
+	// accepts legacy private network IDs as well
 	if networkIDs, networkOK := d.GetOk("private_network_ids"); networkOK {
 		for _, v := range networkIDs.(*schema.Set).List() {
 			req.AttachVPC = append(req.AttachVPC, v.(string))
@@ -356,8 +416,28 @@ func resourceVultrInstanceCreate(ctx context.Context, d *schema.ResourceData, me
 		}
 	}
 
+	appVariables := map[string]string{}
+	for k, v := range d.Get("app_variables").(map[string]interface{}) {
+		appVariables[k] = v.(string)
+	}
+
+	// Marketplace apps are the only ones that take variables, and the API
+	// does not report a missing required variable until the install fails.
+	if len(appVariables) != 0 && osOption != "image_id" {
+		return diag.Errorf("app_variables can only be used with image_id")
+	}
+	if osOption == "image_id" {
+		variables, err := getMarketplaceAppVariables(ctx, client, req.ImageID)
+		if err != nil {
+			return diag.Errorf("error getting variables for marketplace app %s: %v", req.ImageID, err)
+		}
+		if missing := missingAppVariables(variables, appVariables); len(missing) != 0 {
+			return diag.Errorf("marketplace app %s requires app_variables: %s", req.ImageID, strings.Join(missing, ", "))
+		}
+	}
+
 	log.Printf("[INFO] Creating server")
-	instance, err := client.Instance.Create(ctx, req)
+	instance, err := createInstance(ctx, client, req, appVariables)
 	if err != nil {
 		return diag.Errorf("error creating server: %v", err)
 	}
@@ -373,6 +453,25 @@ func resourceVultrInstanceCreate(ctx context.Context, d *schema.ResourceData, me
 		return diag.Errorf("error while waiting for Server %s to be in a active state : %s", d.Id(), err)
 	}
 
+	// Instances booted from an ISO wait on a manual install and never report a server_status of ok
+	if osOption != "iso_id" {
+		if _, err = waitForServerAvailable(ctx, d, "ok", []string{"none", "locked", "installingbooting", "isomounting"}, "server_status", meta); err != nil {
+			return diag.Errorf("error while waiting for Server %s to have a server status of ok : %s", d.Id(), err)
+		}
+	}
+
+	if snapshotID := d.Get("restore_snapshot_id").(string); snapshotID != "" {
+		if err := restoreInstanceSnapshot(ctx, d, snapshotID, meta); err != nil {
+			return diag.Errorf("error restoring instance %s from snapshot %s : %v", d.Id(), snapshotID, err)
+		}
+	}
+
+	if d.Get("desired_state").(string) == "stopped" {
+		if err := setInstancePowerState(ctx, d, "stopped", meta); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if backups == "enabled" {
 		backupReq := generateBackupSchedule(backupSchedule)
 		if err := client.Instance.SetBackupSchedule(context.Background(), instance.ID, backupReq); err != nil {
@@ -386,9 +485,9 @@ func resourceVultrInstanceCreate(ctx context.Context, d *schema.ResourceData, me
 func resourceVultrInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
-	instance, err := client.Instance.Get(ctx, d.Id())
+	instance, err := getInstance(ctx, client, d.Id(), d.IsNewResource())
 	if err != nil {
-		if strings.Contains(err.Error(), "invalid instance ID") {
+		if isInstanceNotFound(err) && !d.IsNewResource() {
 			log.Printf("[WARN] Removing instance (%s) because it is gone", d.Id())
 			d.SetId("")
 			return nil
@@ -407,22 +506,48 @@ func resourceVultrInstanceRead(ctx context.Context, d *schema.ResourceData, meta
 	d.Set("netmask_v4", instance.NetmaskV4)
 	d.Set("gateway_v4", instance.GatewayV4)
 	d.Set("power_status", instance.PowerStatus)
+
+	// A managed power state follows the instance, so an instance started or
+	// stopped outside of terraform shows up as a diff
+	if d.Get("desired_state").(string) != "" {
+		d.Set("desired_state", instance.PowerStatus)
+	}
 	d.Set("server_status", instance.ServerStatus)
+
+	iso, err := client.Instance.ISOStatus(ctx, d.Id())
+	if err != nil {
+		return diag.Errorf("error getting iso status for instance %s : %v", d.Id(), err)
+	}
+	d.Set("iso_status", iso.State)
 	d.Set("internal_ip", instance.InternalIP)
 	d.Set("kvm", instance.KVM)
 	d.Set("v6_network", instance.V6Network)
 	d.Set("v6_main_ip", instance.V6MainIP)
 	d.Set("v6_network_size", instance.V6NetworkSize)
-	d.Set("tag", instance.Tag)
-	d.Set("tags", instance.Tags)
+	d.Set("tag", meta.(*Client).stateTag(d.Get("tag").(string), instance.Tag))
+	_, configuredTags := tfChangeToSlices("tags", d)
+	d.Set("tags", meta.(*Client).stateTags(configuredTags, instance.Tags))
 	d.Set("firewall_group_id", instance.FirewallGroupID)
 	d.Set("region", instance.Region)
 	d.Set("plan", instance.Plan)
 	d.Set("os_id", instance.OsID)
 	d.Set("app_id", instance.AppID)
 	d.Set("features", instance.Features)
+	d.Set("ddos_protection", instanceHasFeature(instance, "ddos_protection"))
 	d.Set("hostname", instance.Hostname)
 
+	userData, err := client.Instance.GetUserData(ctx, d.Id())
+	if err != nil {
+		return diag.Errorf("error getting user data for instance %s : %v", d.Id(), err)
+	}
+
+	// The API returns user data base64 encoded, while the schema holds the plain text
+	decoded, err := base64.StdEncoding.DecodeString(userData.Data)
+	if err != nil {
+		return diag.Errorf("error decoding user data for instance %s : %v", d.Id(), err)
+	}
+	d.Set("user_data", string(decoded))
+
 	backup, err := client.Instance.GetBackupSchedule(ctx, d.Id())
 	if err != nil {
 		return diag.Errorf("error getting backup schedule: %v", err)
@@ -433,10 +558,11 @@ func resourceVultrInstanceRead(ctx context.Context, d *schema.ResourceData, meta
 	if backupStatus(backup.Enabled) != "disabled" {
 		var bs []map[string]interface{}
 		backupScheduleInfo := map[string]interface{}{
-			"type": backup.Type,
-			"hour": backup.Hour,
-			"dom":  backup.Dom,
-			"dow":  backup.Dow,
+			"type":                    backup.Type,
+			"hour":                    backup.Hour,
+			"dom":                     backup.Dom,
+			"dow":                     backup.Dow,
+			"next_scheduled_time_utc": backup.NextScheduleTimeUTC,
 		}
 		bs = append(bs, backupScheduleInfo)
 
@@ -447,11 +573,19 @@ func resourceVultrInstanceRead(ctx context.Context, d *schema.ResourceData, meta
 		d.Set("backups_schedule", nil)
 	}
 
-	vpcs, err := getVPCs(client, d.Id())
+	vpcInfo, err := getVPCInfo(client, d.Id())
 	if err != nil {
 		return diag.Errorf(err.Error())
 	}
 
+	var vpcs []string
+	vpcIPs := map[string]string{}
+	for _, v := range vpcInfo {
+		vpcs = append(vpcs, v.ID)
+		vpcIPs[v.ID] = v.IPAddress
+	}
+	d.Set("vpc_internal_ips", vpcIPs)
+
 	// Manipulate the read state so that, depending on which value was passed,
 	// only one of these values is populated when a VPC or PN is defined for
 	// the instance
@@ -473,10 +607,14 @@ func resourceVultrInstanceUpdate(ctx context.Context, d *schema.ResourceData, me
 	client := meta.(*Client).govultrClient()
 
 	req := &govultr.InstanceUpdateReq{
-		Label:           d.Get("label").(string),
-		Tag:             govultr.StringToStringPtr(d.Get("tag").(string)),
-		FirewallGroupID: d.Get("firewall_group_id").(string),
-		EnableIPv6:      govultr.BoolToBoolPtr(d.Get("enable_ipv6").(bool)),
+		Label:      d.Get("label").(string),
+		Tag:        govultr.StringToStringPtr(meta.(*Client).tagOrDefault(d.Get("tag").(string))),
+		EnableIPv6: govultr.BoolToBoolPtr(d.Get("enable_ipv6").(bool)),
+	}
+
+	if d.HasChange("firewall_group_id") {
+		log.Printf("[INFO] Updating firewall_group_id")
+		req.FirewallGroupID = d.Get("firewall_group_id").(string)
 	}
 
 	if d.HasChange("plan") {
@@ -514,71 +652,71 @@ func resourceVultrInstanceUpdate(ctx context.Context, d *schema.ResourceData, me
 	if d.HasChange("private_network_ids") {
 		log.Printf("[INFO] Updating private_network_ids")
 		oldNetwork, newNetwork := d.GetChange("private_network_ids")
-
-		var oldIDs []string
-		for _, v := range oldNetwork.(*schema.Set).List() {
-			oldIDs = append(oldIDs, v.(string))
-		}
-
-		var newIDs []string
-		for _, v := range newNetwork.(*schema.Set).List() {
-			newIDs = append(newIDs, v.(string))
-		}
-
-		for _, v := range diffSlice(oldIDs, newIDs) {
-			req.AttachPrivateNetwork = append(req.AttachPrivateNetwork, v)
-		}
-
-		for _, v := range diffSlice(newIDs, oldIDs) {
-			req.DetachPrivateNetwork = append(req.DetachPrivateNetwork, v)
-		}
-
+		req.AttachPrivateNetwork, req.DetachPrivateNetwork = networkIDChanges(oldNetwork.(*schema.Set), newNetwork.(*schema.Set))
 	}
 
 	if d.HasChange("vpc_ids") {
 		log.Printf("[INFO] Updating vpc_ids")
 		oldVPC, newVPC := d.GetChange("vpc_ids")
+		req.AttachVPC, req.DetachVPC = networkIDChanges(oldVPC.(*schema.Set), newVPC.(*schema.Set))
+	}
 
-		var oldIDs []string
-		for _, v := range oldVPC.(*schema.Set).List() {
-			oldIDs = append(oldIDs, v.(string))
-		}
+	if d.HasChange("tags") {
+		_, newTags := tfChangeToSlices("tags", d)
+		req.Tags = meta.(*Client).tagsOrDefault(newTags)
+	}
 
-		var newIDs []string
-		for _, v := range newVPC.(*schema.Set).List() {
-			newIDs = append(newIDs, v.(string))
-		}
+	updateReq := &instanceUpdateReq{InstanceUpdateReq: req}
+	if d.HasChange("plan") {
+		updateReq.ResizeDisk = govultr.BoolToBoolPtr(d.Get("resize_disk").(bool))
+	}
 
-		for _, v := range diffSlice(oldIDs, newIDs) {
-			req.AttachVPC = append(req.AttachVPC, v)
-		}
+	if err := updateInstance(ctx, client, d.Id(), updateReq); err != nil {
+		return diag.Errorf("error updating instance %s : %s", d.Id(), err.Error())
+	}
 
-		for _, v := range diffSlice(newIDs, oldIDs) {
-			req.DetachVPC = append(req.DetachVPC, v)
+	if d.HasChange("enable_ipv6") {
+		if err := waitForInstanceIPv6(ctx, client, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.Errorf("error while waiting for instance %s to have an IPv6 address : %v", d.Id(), err)
 		}
 	}
 
-	if d.HasChange("tags") {
-		_, newTags := tfChangeToSlices("tags", d)
-		req.Tags = newTags
+	if d.HasChange("restore_snapshot_id") {
+		if snapshotID := d.Get("restore_snapshot_id").(string); snapshotID != "" {
+			if err := restoreInstanceSnapshot(ctx, d, snapshotID, meta); err != nil {
+				return diag.Errorf("error restoring instance %s from snapshot %s : %v", d.Id(), snapshotID, err)
+			}
+		}
 	}
 
-	if _, err := client.Instance.Update(ctx, d.Id(), req); err != nil {
-		return diag.Errorf("error updating instance %s : %s", d.Id(), err.Error())
+	if d.HasChange("firewall_group_id") && req.FirewallGroupID == "" {
+		if err := detachInstanceFirewallGroup(ctx, client, d.Id()); err != nil {
+			return diag.Errorf("error detaching firewall group from instance %s : %v", d.Id(), err)
+		}
 	}
 
 	if d.HasChange("iso_id") {
 		log.Printf("[INFO] Updating ISO")
 
-		_, newISOId := d.GetChange("iso_id")
-		if newISOId == "" {
+		// An instance mounts one ISO at a time, so swapping ISOs detaches the
+		// current one first
+		oldISOId, newISOId := d.GetChange("iso_id")
+		if oldISOId != "" {
 			if err := client.Instance.DetachISO(ctx, d.Id()); err != nil {
 				return diag.Errorf("error detaching iso from instance %s : %v", d.Id(), err)
 			}
-		} else {
+			if err := waitForInstanceISO(ctx, client, d.Id(), "", d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return diag.Errorf("error waiting for iso to be detached from instance %s : %v", d.Id(), err)
+			}
+		}
+
+		if newISOId != "" {
 			if err := client.Instance.AttachISO(ctx, d.Id(), newISOId.(string)); err != nil {
 				return diag.Errorf("error attaching iso to instance %s : %v", d.Id(), err)
 			}
+			if err := waitForInstanceISO(ctx, client, d.Id(), newISOId.(string), d.Timeout(schema.TimeoutUpdate)); err != nil {
+				return diag.Errorf("error waiting for iso to be attached to instance %s : %v", d.Id(), err)
+			}
 		}
 	}
 
@@ -602,11 +740,129 @@ func resourceVultrInstanceUpdate(ctx context.Context, d *schema.ResourceData, me
 		if _, err := waitForUpgrade(ctx, d, newP.(string), []string{oldP.(string)}, "plan", meta); err != nil {
 			return diag.Errorf("error while waiting for instance %s to have updated plan : %s", d.Id(), err)
 		}
+
+		if _, err := waitForServerAvailable(ctx, d, "active", []string{"pending", "resizing"}, "status", meta); err != nil {
+			return diag.Errorf("error while waiting for instance %s to be active after the resize : %s", d.Id(), err)
+		}
+	}
+
+	if state := d.Get("desired_state").(string); d.HasChange("desired_state") && state != "" {
+		if err := setInstancePowerState(ctx, d, state, meta); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourceVultrInstanceRead(ctx, d, meta)
 }
 
+// setInstancePowerState starts or halts the instance and waits for its power
+// status to match. A halted instance keeps its status of active.
+func setInstancePowerState(ctx context.Context, d *schema.ResourceData, state string, meta interface{}) error {
+	client := meta.(*Client).govultrClient()
+
+	var pending []string
+	switch state {
+	case "running":
+		log.Printf("[INFO] Starting instance (%s)", d.Id())
+		if err := client.Instance.Start(ctx, d.Id()); err != nil {
+			return fmt.Errorf("error starting instance %s : %v", d.Id(), err)
+		}
+		pending = []string{"stopped"}
+	case "stopped":
+		log.Printf("[INFO] Halting instance (%s)", d.Id())
+		if err := client.Instance.Halt(ctx, d.Id()); err != nil {
+			return fmt.Errorf("error halting instance %s : %v", d.Id(), err)
+		}
+		pending = []string{"running"}
+	default:
+		return fmt.Errorf("unknown desired_state %q", state)
+	}
+
+	if _, err := waitForServerAvailable(ctx, d, state, pending, "power_status", meta); err != nil {
+		return fmt.Errorf("error while waiting for instance %s to be %s : %v", d.Id(), state, err)
+	}
+
+	return nil
+}
+
+func resourceVultrInstanceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// os_id, app_id and image_id are computed and an ISO can be mounted on
+	// any existing instance, so the source is only checked on create.
+	if d.Id() == "" {
+		if err := checkInstanceSource(d.GetRawConfig()); err != nil {
+			return err
+		}
+	}
+
+	// A plan can only be changed in place to one of the upgrades the API
+	// offers for the instance. Any other plan is rejected unless replacing the
+	// instance, and losing its disk, was opted into.
+	if d.Id() != "" && d.HasChange("plan") && d.NewValueKnown("plan") {
+		client := meta.(*Client).govultrClient()
+		upgrades, err := client.Instance.GetUpgrades(ctx, d.Id())
+		if err != nil {
+			return fmt.Errorf("error getting upgrades for instance %s : %v", d.Id(), err)
+		}
+
+		if err := checkInstancePlanUpgrade(d.Id(), d.Get("plan").(string), upgrades.Plans); err != nil {
+			if !d.Get("recreate_for_incompatible_plan").(bool) {
+				return fmt.Errorf("%v, set recreate_for_incompatible_plan to replace the instance instead", err)
+			}
+
+			if err := d.ForceNew("plan"); err != nil {
+				return err
+			}
+		}
+	}
+
+	// IPv6 can be enabled on an existing instance, but the API has no way to
+	// disable it again.
+	if d.Id() != "" && d.HasChange("enable_ipv6") {
+		if !d.Get("enable_ipv6").(bool) {
+			return fmt.Errorf("enable_ipv6 cannot be changed back to false, IPv6 cannot be disabled on an existing instance")
+		}
+
+		for _, k := range []string{"v6_network", "v6_main_ip", "v6_network_size"} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+	}
+
+	// dow and dom are computed, so only the configuration tells whether they
+	// were set for a schedule type that ignores them.
+	if raw := d.GetRawConfig(); raw.IsKnown() && !raw.IsNull() {
+		if schedules := raw.GetAttr("backups_schedule"); schedules.IsKnown() && !schedules.IsNull() {
+			for _, schedule := range schedules.AsValueSlice() {
+				scheduleType := schedule.GetAttr("type")
+				if !scheduleType.IsKnown() || scheduleType.IsNull() {
+					continue
+				}
+
+				if err := validateBackupSchedule(scheduleType.AsString(), !schedule.GetAttr("dow").IsNull(), !schedule.GetAttr("dom").IsNull()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// DDoS protection is only sold in some regions. Only check when it is
+	// being turned on so existing instances do not need a region lookup.
+	if d.Get("ddos_protection").(bool) && (d.Id() == "" || d.HasChange("ddos_protection")) && d.NewValueKnown("region") {
+		client := meta.(*Client).govultrClient()
+		region, err := getRegion(ctx, client, d.Get("region").(string))
+		if err != nil {
+			return err
+		}
+
+		if !regionHasOption(region, "ddos_protection") {
+			return fmt.Errorf("ddos_protection is not available in region %s", region.ID)
+		}
+	}
+
+	return nil
+}
+
 func resourceVultrInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*Client).govultrClient()
@@ -641,6 +897,40 @@ func resourceVultrInstanceDelete(ctx context.Context, d *schema.ResourceData, me
 	return nil
 }
 
+// instanceSources are the arguments that pick what is installed on a new
+// instance. Exactly one of them must be set.
+var instanceSources = []string{"os_id", "app_id", "image_id", "iso_id", "snapshot_id"}
+
+// checkInstanceSource returns an error unless exactly one of the instance
+// sources is set in config. Unknown values count as set and empty values as
+// unset.
+func checkInstanceSource(config cty.Value) error {
+	if !config.IsKnown() || config.IsNull() {
+		return nil
+	}
+
+	var set []string
+	for _, k := range instanceSources {
+		v := config.GetAttr(k)
+		if v.IsNull() {
+			continue
+		}
+		if v.IsKnown() && (v.RawEquals(cty.StringVal("")) || v.RawEquals(cty.NumberIntVal(0))) {
+			continue
+		}
+		set = append(set, k)
+	}
+
+	switch len(set) {
+	case 0:
+		return fmt.Errorf("one of %s must be set", strings.Join(instanceSources, ", "))
+	case 1:
+		return nil
+	default:
+		return fmt.Errorf("only one of %s can be set, got %s", strings.Join(instanceSources, ", "), strings.Join(set, " and "))
+	}
+}
+
 func optionCheck(options map[string]bool) (string, error) {
 
 	var result []string
@@ -687,6 +977,11 @@ func newServerStateRefresh(ctx context.Context, d *schema.ResourceData, meta int
 		log.Printf("[INFO] Creating Server")
 		server, err := client.Instance.Get(ctx, d.Id())
 		if err != nil {
+			// New instances can briefly be missing from the API, a nil
+			// result lets NotFoundChecks decide when to give up
+			if isInstanceNotFound(err) {
+				return nil, "", nil
+			}
 			return nil, "", fmt.Errorf("error retrieving Server %s : %s", d.Id(), err)
 		}
 
@@ -696,6 +991,9 @@ func newServerStateRefresh(ctx context.Context, d *schema.ResourceData, meta int
 		} else if attr == "power_status" {
 			log.Printf("[INFO] The Server Power Status is %s", server.PowerStatus)
 			return server, server.PowerStatus, nil
+		} else if attr == "server_status" {
+			log.Printf("[INFO] The Server Server Status is %s", server.ServerStatus)
+			return server, server.ServerStatus, nil
 		} else {
 			return nil, "", nil
 		}
@@ -734,6 +1032,160 @@ func newInstancePlanRefresh(ctx context.Context, d *schema.ResourceData, meta in
 	}
 }
 
+// checkInstancePlanUpgrade rejects plans that the instance can't be resized
+// to in place, naming the upgrades the API offers instead.
+func checkInstancePlanUpgrade(instanceID, plan string, upgrades []string) error {
+	for _, p := range upgrades {
+		if p == plan {
+			return nil
+		}
+	}
+
+	if len(upgrades) == 0 {
+		return fmt.Errorf("plan %s is not an upgrade for instance %s, no upgrades are available for it", plan, instanceID)
+	}
+
+	return fmt.Errorf("plan %s is not an upgrade for instance %s, valid upgrades are: %s", plan, instanceID, strings.Join(upgrades, ", "))
+}
+
+// instanceUpdateReq is an instance update including the resize_disk field
+// that govultr.InstanceUpdateReq has no field for.
+type instanceUpdateReq struct {
+	*govultr.InstanceUpdateReq
+	ResizeDisk *bool `json:"resize_disk,omitempty"`
+}
+
+// updateInstance updates an instance directly so that the fields of
+// instanceUpdateReq are sent.
+func updateInstance(ctx context.Context, client *govultr.Client, instanceID string, updateReq *instanceUpdateReq) error {
+	req, err := client.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("/v2/instances/%s", instanceID), updateReq)
+	if err != nil {
+		return err
+	}
+
+	return client.DoWithContext(ctx, req, nil)
+}
+
+// restoreInstanceSnapshot replaces the disk of the instance with the contents
+// of the snapshot. This is destructive: any data written to the instance since
+// the snapshot was taken is lost.
+func restoreInstanceSnapshot(ctx context.Context, d *schema.ResourceData, snapshotID string, meta interface{}) error {
+	client := meta.(*Client).govultrClient()
+
+	log.Printf("[INFO] Restoring instance (%s) from snapshot (%s)", d.Id(), snapshotID)
+	if err := client.Instance.Restore(ctx, d.Id(), &govultr.RestoreReq{SnapshotID: snapshotID}); err != nil {
+		return err
+	}
+
+	if _, err := waitForServerAvailable(ctx, d, "active", []string{"pending", "installing"}, "status", meta); err != nil {
+		return fmt.Errorf("error while waiting for the instance to be active: %v", err)
+	}
+
+	if _, err := waitForServerAvailable(ctx, d, "ok", []string{"none", "locked", "installingbooting"}, "server_status", meta); err != nil {
+		return fmt.Errorf("error while waiting for the instance to have a server status of ok: %v", err)
+	}
+
+	return nil
+}
+
+// detachInstanceFirewallGroup removes the firewall group from an instance.
+// govultr.InstanceUpdateReq omits an empty firewall_group_id, so the request
+// is built here to send it explicitly.
+func detachInstanceFirewallGroup(ctx context.Context, client *govultr.Client, instanceID string) error {
+	body := map[string]string{"firewall_group_id": ""}
+	req, err := client.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("/v2/instances/%s", instanceID), body)
+	if err != nil {
+		return err
+	}
+
+	return client.DoWithContext(ctx, req, nil)
+}
+
+// getInstance fetches an instance. A new instance can be missing from the API
+// for a moment after it is created, so when retryNotFound is set not found
+// errors are retried for up to instanceNotFoundTimeout.
+func getInstance(ctx context.Context, client *govultr.Client, instanceID string, retryNotFound bool) (*govultr.Instance, error) {
+	if !retryNotFound {
+		return client.Instance.Get(ctx, instanceID)
+	}
+
+	var instance *govultr.Instance
+	err := resource.RetryContext(ctx, instanceNotFoundTimeout, func() *resource.RetryError {
+		var err error
+		instance, err = client.Instance.Get(ctx, instanceID)
+		if err != nil {
+			if isInstanceNotFound(err) {
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(err)
+		}
+		return nil
+	})
+
+	return instance, err
+}
+
+// isInstanceNotFound reports whether err is the API response for an instance
+// that does not exist.
+func isInstanceNotFound(err error) bool {
+	return strings.Contains(err.Error(), "invalid instance ID") || strings.Contains(err.Error(), "\"status\":404")
+}
+
+// instanceHasFeature reports whether an optional feature such as
+// "ddos_protection" is enabled on the instance.
+func instanceHasFeature(instance *govultr.Instance, feature string) bool {
+	for _, f := range instance.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// userDataEqual reports whether two user data values only differ by trailing
+// whitespace.
+func userDataEqual(old, new string) bool {
+	return strings.TrimRightFunc(old, unicode.IsSpace) == strings.TrimRightFunc(new, unicode.IsSpace)
+}
+
+// userDataEquivalent reports whether two user data values have the same
+// content once decoded, ignoring trailing whitespace. Empty and whitespace
+// only values are equivalent.
+func userDataEquivalent(old, new string) bool {
+	return userDataEqual(old, new) || userDataEqual(decodeUserData(old), decodeUserData(new))
+}
+
+// decodeUserData returns the decoded value when data is base64 encoded text,
+// and data itself otherwise.
+func decodeUserData(data string) string {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(data))
+	if err != nil || !utf8.Valid(decoded) {
+		return data
+	}
+	return string(decoded)
+}
+
+// encodeUserData base64 encodes user data for the API. Data that is already
+// base64 encoded is sent as is, matching userDataEquivalent, which treats it
+// as the same user data as its plain text.
+func encodeUserData(data string) string {
+	return base64.StdEncoding.EncodeToString([]byte(decodeUserData(data)))
+}
+
+// validateBackupSchedule checks that dow is only set for weekly schedules and
+// dom only for monthly ones.
+func validateBackupSchedule(scheduleType string, dowSet, domSet bool) error {
+	if dowSet && scheduleType != "weekly" {
+		return fmt.Errorf("backups_schedule dow can only be set when type is weekly, got %s", scheduleType)
+	}
+
+	if domSet && scheduleType != "monthly" {
+		return fmt.Errorf("backups_schedule dom can only be set when type is monthly, got %s", scheduleType)
+	}
+
+	return nil
+}
+
 func generateBackupSchedule(backup interface{}) *govultr.BackupScheduleReq {
 	k := backup.([]interface{})
 
@@ -86,7 +86,7 @@ func resourceVultrInstanceIPV4Read(ctx context.Context, d *schema.ResourceData,
 			}
 		}
 
-		if meta.Links.Next == "" {
+		if ipv4 != nil || meta.Links.Next == "" {
 			break
 		} else {
 			options.Cursor = meta.Links.Next
@@ -103,6 +103,8 @@ func resourceVultrInstanceIPV4Read(ctx context.Context, d *schema.ResourceData,
 	d.Set("ip", ipv4.IP)
 	d.Set("instance_id", instanceID)
 	d.Set("reverse", ipv4.Reverse)
+	d.Set("gateway", ipv4.Gateway)
+	d.Set("netmask", ipv4.Netmask)
 	d.Set("reboot", d.Get("reboot").(bool))
 
 	return nil
@@ -28,6 +28,8 @@ func TestAccVultrInstanceIPV4Basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet(name, "instance_id"),
 					resource.TestCheckResourceAttrSet(name, "ip"),
 					resource.TestCheckResourceAttrSet(name, "reverse"),
+					resource.TestCheckResourceAttrSet(name, "gateway"),
+					resource.TestCheckResourceAttrSet(name, "netmask"),
 				),
 			},
 		},
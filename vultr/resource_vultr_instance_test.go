@@ -2,13 +2,21 @@ package vultr
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"regexp"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vultr/govultr/v2"
 )
 
 func TestAccVultrInstanceBasic(t *testing.T) {
@@ -32,6 +40,7 @@ func TestAccVultrInstanceBasic(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "os_id", "167"),
 					resource.TestCheckResourceAttr(name, "status", "active"),
 					resource.TestCheckResourceAttr(name, "power_status", "running"),
+					resource.TestCheckResourceAttr(name, "server_status", "ok"),
 					resource.TestCheckResourceAttr(name, "region", "sea"),
 					resource.TestCheckResourceAttr(name, "tag", "even better tag"),
 					resource.TestCheckResourceAttr(name, "tags.#", "2"),
@@ -40,11 +49,574 @@ func TestAccVultrInstanceBasic(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "backups_schedule.0.type", "weekly"),
 					resource.TestCheckResourceAttr(name, "backups_schedule.0.dow", "4"),
 					resource.TestCheckResourceAttr(name, "backups_schedule.0.hour", "11"),
+					resource.TestCheckResourceAttrSet(name, "backups_schedule.0.next_scheduled_time_utc"),
 				),
 			},
 		},
 	})
 }
+
+func TestAccVultrInstanceBackupScheduleInvalid(t *testing.T) {
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-vps-rs")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "vultr_instance" "test" {
+						plan = "vc2-1c-1gb"
+						region = "sea"
+						os_id = "167"
+						label = "%s"
+						backups = "enabled"
+						backups_schedule {
+							type = "daily"
+							dow = 4
+						}
+					}`, rName),
+				ExpectError: regexp.MustCompile("dow can only be set when type is weekly"),
+			},
+		},
+	})
+}
+
+func TestValidateBackupSchedule(t *testing.T) {
+	tests := []struct {
+		scheduleType string
+		dow, dom     bool
+		valid        bool
+	}{
+		{"daily", false, false, true},
+		{"weekly", true, false, true},
+		{"monthly", false, true, true},
+		{"daily", true, false, false},
+		{"weekly", false, true, false},
+		{"monthly", true, false, false},
+		{"daily_alt_even", false, true, false},
+	}
+
+	for _, tt := range tests {
+		err := validateBackupSchedule(tt.scheduleType, tt.dow, tt.dom)
+		if (err == nil) != tt.valid {
+			t.Errorf("validateBackupSchedule(%q, %v, %v) returned %v", tt.scheduleType, tt.dow, tt.dom, err)
+		}
+	}
+}
+
+func TestAccVultrInstanceUserData(t *testing.T) {
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-vps-rs")
+
+	name := "vultr_instance.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckVultrInstanceDestroy,
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVultrInstanceUserData(rName, "#cloud-config\\npackages:\\n  - git"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "user_data", "#cloud-config\npackages:\n  - git"),
+				),
+			},
+			{
+				Config:   testAccVultrInstanceUserData(rName, "#cloud-config\\npackages:\\n  - git\\n\\n"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestUserDataEqual(t *testing.T) {
+	tests := []struct {
+		old, new string
+		expected bool
+	}{
+		{"#cloud-config", "#cloud-config", true},
+		{"#cloud-config", "#cloud-config\n", true},
+		{"#cloud-config\n", "#cloud-config \t\r\n", true},
+		{"", "\n", true},
+		{"#cloud-config", " #cloud-config", false},
+		{"#cloud-config", "#cloud-config\npackages: []", false},
+	}
+
+	for _, tt := range tests {
+		if got := userDataEqual(tt.old, tt.new); got != tt.expected {
+			t.Errorf("userDataEqual(%q, %q) = %v, expected %v", tt.old, tt.new, got, tt.expected)
+		}
+	}
+}
+
+func TestUserDataEquivalent(t *testing.T) {
+	tests := []struct {
+		old, new string
+		expected bool
+	}{
+		{"#cloud-config\n", "#cloud-config", true},
+		{"#cloud-config", "I2Nsb3VkLWNvbmZpZw==", true},
+		{"I2Nsb3VkLWNvbmZpZw==", "#cloud-config\n", true},
+		{"I2Nsb3VkLWNvbmZpZw==\n", "I2Nsb3VkLWNvbmZpZw==", true},
+		{"", "", true},
+		{"", " \n\t", true},
+		{"\n", "", true},
+		{"", "#cloud-config", false},
+		{"#cloud-config", "I2Nsb3VkLWNvbmZpZzI=", false},
+	}
+
+	for _, tt := range tests {
+		if got := userDataEquivalent(tt.old, tt.new); got != tt.expected {
+			t.Errorf("userDataEquivalent(%q, %q) = %v, expected %v", tt.old, tt.new, got, tt.expected)
+		}
+	}
+}
+
+func TestEncodeUserData(t *testing.T) {
+	for _, data := range []string{"#cloud-config", "I2Nsb3VkLWNvbmZpZw==", "I2Nsb3VkLWNvbmZpZw==\n"} {
+		if got := encodeUserData(data); got != "I2Nsb3VkLWNvbmZpZw==" {
+			t.Errorf("encodeUserData(%q) = %q, expected I2Nsb3VkLWNvbmZpZw==", data, got)
+		}
+	}
+}
+
+func TestResourceVultrInstanceReadNotFound(t *testing.T) {
+	var instanceRequests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/instances/instance":
+			n := atomic.AddInt32(&instanceRequests, 1)
+			if n < 3 {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error":"invalid instance ID","status":404}`)
+				return
+			}
+			fmt.Fprint(w, `{"instance":{"id":"instance","status":"active","region":"ewr"}}`)
+		case "/v2/instances/instance/user-data":
+			fmt.Fprint(w, `{"user_data":{"data":""}}`)
+		case "/v2/instances/instance/iso":
+			fmt.Fprint(w, `{"iso_status":{"state":"ready","iso_id":""}}`)
+		case "/v2/instances/instance/backup-schedule":
+			fmt.Fprint(w, `{"backup_schedule":{"enabled":false}}`)
+		case "/v2/instances/instance/vpcs":
+			fmt.Fprint(w, `{"vpcs":[],"meta":{"total":0,"links":{"next":"","prev":""}}}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	// An existing instance that is not found was deleted outside of terraform
+	d := resourceVultrInstance().TestResourceData()
+	d.SetId("instance")
+	if diags := resourceVultrInstanceRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected instance to be removed from state, got ID %q", d.Id())
+	}
+
+	// A new instance is retried until the API returns it
+	d = resourceVultrInstance().TestResourceData()
+	d.SetId("instance")
+	d.MarkNewResource()
+	if diags := resourceVultrInstanceRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "instance" || d.Get("status").(string) != "active" {
+		t.Fatalf("expected the new instance to be read, got ID %q with status %q", d.Id(), d.Get("status"))
+	}
+	if n := atomic.LoadInt32(&instanceRequests); n != 3 {
+		t.Fatalf("expected 3 requests for the instance, got %d", n)
+	}
+}
+
+func TestNetworkIDChanges(t *testing.T) {
+	oldIDs := schema.NewSet(schema.HashString, []interface{}{"net-1", "net-2"})
+	newIDs := schema.NewSet(schema.HashString, []interface{}{"net-2", "net-3"})
+
+	attach, detach := networkIDChanges(oldIDs, newIDs)
+	if len(attach) != 1 || attach[0] != "net-3" {
+		t.Errorf("expected to attach net-3, got %v", attach)
+	}
+	if len(detach) != 1 || detach[0] != "net-1" {
+		t.Errorf("expected to detach net-1, got %v", detach)
+	}
+
+	attach, detach = networkIDChanges(newIDs, newIDs)
+	if len(attach) != 0 || len(detach) != 0 {
+		t.Errorf("expected no changes, got attach %v and detach %v", attach, detach)
+	}
+}
+
+func TestResourceVultrInstanceDiffInPlace(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "instance",
+		Attributes: map[string]string{
+			"id":       "instance",
+			"region":   "ewr",
+			"plan":     "vc2-1c-1gb",
+			"os_id":    "1743",
+			"label":    "web",
+			"tag":      "old",
+			"tags.#":   "1",
+			"tags.0":   "web",
+			"hostname": "web",
+		},
+	}
+	base := map[string]interface{}{
+		"region":   "ewr",
+		"plan":     "vc2-1c-1gb",
+		"os_id":    1743,
+		"label":    "web",
+		"tag":      "old",
+		"tags":     []interface{}{"web"},
+		"hostname": "web",
+	}
+
+	cases := map[string]struct {
+		key        string
+		value      interface{}
+		requireNew bool
+	}{
+		"label":    {"label", "renamed", false},
+		"tag":      {"tag", "new", false},
+		"tags":     {"tags", []interface{}{"web", "prod"}, false},
+		"hostname": {"hostname", "renamed", true},
+	}
+
+	r := resourceVultrInstance()
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			raw := map[string]interface{}{}
+			for k, v := range base {
+				raw[k] = v
+			}
+			raw[tc.key] = tc.value
+
+			diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), &Client{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff == nil || diff.Attributes[tc.key] == nil && diff.Attributes[tc.key+".#"] == nil {
+				t.Fatalf("expected a diff for %s", tc.key)
+			}
+			if diff.RequiresNew() != tc.requireNew {
+				for k, a := range diff.Attributes {
+					if a.RequiresNew {
+						t.Logf("%s requires a new instance", k)
+					}
+				}
+				t.Errorf("changing %s: requires new = %t, expected %t", tc.key, diff.RequiresNew(), tc.requireNew)
+			}
+		})
+	}
+}
+
+func TestResourceVultrInstanceDiffIPv6(t *testing.T) {
+	state := func(enabled string) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "instance",
+			Attributes: map[string]string{
+				"id":          "instance",
+				"region":      "ewr",
+				"plan":        "vc2-1c-1gb",
+				"os_id":       "1743",
+				"enable_ipv6": enabled,
+				"v6_main_ip":  "",
+			},
+		}
+	}
+	config := func(enabled bool) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"region":      "ewr",
+			"plan":        "vc2-1c-1gb",
+			"os_id":       1743,
+			"enable_ipv6": enabled,
+		})
+	}
+
+	r := resourceVultrInstance()
+	diff, err := r.Diff(context.Background(), state("false"), config(true), &Client{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff.RequiresNew() {
+		t.Errorf("expected IPv6 to be enabled in place")
+	}
+	if a := diff.Attributes["v6_main_ip"]; a == nil || !a.NewComputed {
+		t.Errorf("expected v6_main_ip to be known after apply, got %#v", a)
+	}
+
+	_, err = r.Diff(context.Background(), state("true"), config(false), &Client{})
+	if err == nil || !strings.Contains(err.Error(), "IPv6 cannot be disabled") {
+		t.Fatalf("expected an error disabling IPv6, got %v", err)
+	}
+}
+
+func TestResourceVultrInstanceReadDesiredState(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/instances/instance":
+			fmt.Fprint(w, `{"instance":{"id":"instance","status":"active","power_status":"running","region":"ewr"}}`)
+		case "/v2/instances/instance/iso":
+			fmt.Fprint(w, `{"iso_status":{"state":"ready","iso_id":""}}`)
+		case "/v2/instances/instance/user-data":
+			fmt.Fprint(w, `{"user_data":{"data":""}}`)
+		case "/v2/instances/instance/backup-schedule":
+			fmt.Fprint(w, `{"backup_schedule":{"enabled":false}}`)
+		case "/v2/instances/instance/vpcs":
+			fmt.Fprint(w, `{"vpcs":[],"meta":{"total":0,"links":{"next":"","prev":""}}}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	// An instance started outside of terraform no longer matches the config
+	d := resourceVultrInstance().TestResourceData()
+	d.SetId("instance")
+	d.Set("desired_state", "stopped")
+	if diags := resourceVultrInstanceRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if state := d.Get("desired_state").(string); state != "running" {
+		t.Fatalf("expected desired_state to follow the power status, got %q", state)
+	}
+
+	// The power state is left alone when it is not managed
+	d = resourceVultrInstance().TestResourceData()
+	d.SetId("instance")
+	if diags := resourceVultrInstanceRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if state := d.Get("desired_state").(string); state != "" {
+		t.Fatalf("expected desired_state to stay unset, got %q", state)
+	}
+}
+
+func TestWaitForInstanceIPv6(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/instances/instance" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		n := atomic.AddInt32(&requests, 1)
+		if n == 1 {
+			fmt.Fprint(w, `{"instance":{"id":"instance"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"instance":{"id":"instance","v6_main_ip":"2001:db8::1"}}`)
+	}).govultrClient()
+
+	if err := waitForInstanceIPv6(context.Background(), client, "instance", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Fatalf("expected to poll until the address was assigned, got %d requests", n)
+	}
+}
+
+func TestCheckInstanceSource(t *testing.T) {
+	values := map[string]cty.Value{
+		"os_id":       cty.NumberIntVal(167),
+		"app_id":      cty.NumberIntVal(2),
+		"image_id":    cty.StringVal("wordpress"),
+		"iso_id":      cty.StringVal("iso"),
+		"snapshot_id": cty.StringVal("snapshot"),
+	}
+
+	// Every combination of the sources, only a single one is valid
+	for mask := 0; mask < 1<<len(instanceSources); mask++ {
+		attrs := map[string]cty.Value{}
+		var set []string
+		for i, k := range instanceSources {
+			if mask&(1<<i) != 0 {
+				attrs[k] = values[k]
+				set = append(set, k)
+			} else {
+				attrs[k] = cty.NullVal(values[k].Type())
+			}
+		}
+
+		err := checkInstanceSource(cty.ObjectVal(attrs))
+		switch {
+		case len(set) == 1 && err != nil:
+			t.Errorf("expected %v to be valid, got %v", set, err)
+		case len(set) != 1 && err == nil:
+			t.Errorf("expected %v to be rejected", set)
+		case len(set) > 1 && !strings.Contains(err.Error(), strings.Join(set, " and ")):
+			t.Errorf("expected the error for %v to name the conflicting fields, got %v", set, err)
+		}
+	}
+
+	// Empty values are unset and unknown values are set
+	err := checkInstanceSource(cty.ObjectVal(map[string]cty.Value{
+		"os_id":       cty.NumberIntVal(0),
+		"app_id":      cty.NullVal(cty.Number),
+		"image_id":    cty.StringVal(""),
+		"iso_id":      cty.UnknownVal(cty.String),
+		"snapshot_id": cty.NullVal(cty.String),
+	}))
+	if err != nil {
+		t.Errorf("expected an unknown iso_id to be the only source, got %v", err)
+	}
+}
+
+func TestWaitForInstanceISO(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/instances/instance/iso" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		n := atomic.AddInt32(&requests, 1)
+		if n == 1 {
+			fmt.Fprint(w, `{"iso_status":{"state":"isomounting","iso_id":"rescue"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"iso_status":{"state":"ready","iso_id":"rescue"}}`)
+	}).govultrClient()
+
+	if err := waitForInstanceISO(context.Background(), client, "instance", "rescue", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Fatalf("expected to poll until the iso was mounted, got %d requests", n)
+	}
+}
+
+func TestCreateInstanceAppVariables(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/instances" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("unexpected error decoding request: %v", err)
+		}
+		if body["image_id"] != "wordpress" || body["region"] != "ewr" {
+			t.Errorf("unexpected instance fields in %v", body)
+		}
+		if vars, ok := body["app_variables"].(map[string]interface{}); !ok || vars["site_name"] != "blog" {
+			t.Errorf("unexpected app_variables in %v", body)
+		}
+		fmt.Fprint(w, `{"instance":{"id":"instance","default_password":"secret"}}`)
+	}).govultrClient()
+
+	req := &govultr.InstanceCreateReq{Region: "ewr", ImageID: "wordpress"}
+	instance, err := createInstance(context.Background(), client, req, map[string]string{"site_name": "blog"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if instance.ID != "instance" || instance.DefaultPassword != "secret" {
+		t.Fatalf("unexpected instance %+v", instance)
+	}
+}
+
+func TestMissingAppVariables(t *testing.T) {
+	variables := []marketplaceAppVariable{
+		{Name: "site_name", Required: true},
+		{Name: "admin_email", Required: true},
+		{Name: "theme"},
+	}
+
+	missing := missingAppVariables(variables, map[string]string{"site_name": "blog"})
+	if len(missing) != 1 || missing[0] != "admin_email" {
+		t.Errorf("expected admin_email to be missing, got %v", missing)
+	}
+
+	if missing := missingAppVariables(variables, map[string]string{"site_name": "blog", "admin_email": "a@example.com"}); len(missing) != 0 {
+		t.Errorf("expected no missing variables, got %v", missing)
+	}
+}
+
+func TestCheckInstancePlanUpgrade(t *testing.T) {
+	upgrades := []string{"vc2-1c-2gb", "vc2-2c-4gb"}
+	for _, plan := range upgrades {
+		if err := checkInstancePlanUpgrade("instance", plan, upgrades); err != nil {
+			t.Errorf("unexpected error for %s: %v", plan, err)
+		}
+	}
+
+	err := checkInstancePlanUpgrade("instance", "vc2-1c-0.5gb", upgrades)
+	if err == nil || !strings.Contains(err.Error(), "valid upgrades are: vc2-1c-2gb, vc2-2c-4gb") {
+		t.Errorf("expected an error naming the valid upgrades, got %v", err)
+	}
+}
+
+func TestResourceVultrInstanceDiffPlan(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/instances/instance/upgrades" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"upgrades":{"plans":["vc2-1c-2gb"]}}`)
+	})
+
+	state := &terraform.InstanceState{
+		ID: "instance",
+		Attributes: map[string]string{
+			"id":     "instance",
+			"region": "ewr",
+			"plan":   "vc2-1c-1gb",
+			"os_id":  "1743",
+		},
+	}
+	config := func(plan string, recreate bool) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"region":                         "ewr",
+			"plan":                           plan,
+			"os_id":                          1743,
+			"recreate_for_incompatible_plan": recreate,
+		})
+	}
+
+	r := resourceVultrInstance()
+	diff, err := r.Diff(context.Background(), state, config("vc2-1c-2gb", false), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff.RequiresNew() {
+		t.Error("expected an upgrade to resize the instance in place")
+	}
+
+	_, err = r.Diff(context.Background(), state, config("vc2-1c-0.5gb", false), client)
+	if err == nil || !strings.Contains(err.Error(), "recreate_for_incompatible_plan") {
+		t.Errorf("expected an incompatible plan to be rejected, got %v", err)
+	}
+
+	diff, err = r.Diff(context.Background(), state, config("vc2-1c-0.5gb", true), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !diff.RequiresNew() {
+		t.Error("expected an incompatible plan to replace the instance when opted in")
+	}
+}
+
+func TestUpdateInstanceResizeDisk(t *testing.T) {
+	var body map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/v2/instances/instance" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("error decoding request: %v", err)
+		}
+		fmt.Fprint(w, `{"instance":{"id":"instance"}}`)
+	}).govultrClient()
+
+	req := &instanceUpdateReq{
+		InstanceUpdateReq: &govultr.InstanceUpdateReq{Plan: "vc2-1c-2gb"},
+		ResizeDisk:        govultr.BoolToBoolPtr(false),
+	}
+	if err := updateInstance(context.Background(), client, "instance", req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if body["plan"] != "vc2-1c-2gb" || body["resize_disk"] != false {
+		t.Errorf("expected plan and resize_disk to be sent, got %v", body)
+	}
+}
+
 func TestAccVultrInstanceUpdate(t *testing.T) {
 	t.Parallel()
 	rName := acctest.RandomWithPrefix("tf-vps-rs-up")
@@ -134,6 +706,66 @@ func TestAccVultrInstanceUpdateFirewall(t *testing.T) {
 	})
 }
 
+func TestAccVultrInstanceFirewallGroup(t *testing.T) {
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-vps-rs-fwg")
+
+	name := "vultr_instance.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckVultrInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVultrInstanceFirewallGroup(rName, "vultr_firewall_group.fwg.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name, "firewall_group_id", "vultr_firewall_group.fwg", "id"),
+				),
+			},
+			{
+				Config: testAccVultrInstanceFirewallGroup(rName, "vultr_firewall_group.other.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name, "firewall_group_id", "vultr_firewall_group.other", "id"),
+				),
+			},
+			{
+				Config: testAccVultrInstanceFirewallGroup(rName, `""`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "firewall_group_id", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVultrInstanceRestoreSnapshot(t *testing.T) {
+	t.Parallel()
+	rName := acctest.RandomWithPrefix("tf-vps-rs-restore")
+
+	name := "vultr_instance.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckVultrInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVultrInstanceRestoreSnapshot(rName, `""`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "restore_snapshot_id", ""),
+				),
+			},
+			{
+				Config: testAccVultrInstanceRestoreSnapshot(rName, "vultr_snapshot.source.id"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(name, "restore_snapshot_id", "vultr_snapshot.source", "id"),
+					resource.TestCheckResourceAttr(name, "status", "active"),
+					resource.TestCheckResourceAttr(name, "server_status", "ok"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccVultrInstanceUpdateVPCIDs(t *testing.T) {
 	t.Parallel()
 	rName := acctest.RandomWithPrefix("tf-vps-rs-upnid")
@@ -169,6 +801,7 @@ func TestAccVultrInstanceUpdateVPCIDs(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "tag", "even better tag"),
 					resource.TestCheckResourceAttr(name, "tags.#", "2"),
 					resource.TestCheckResourceAttr(name, "vpc_ids.#", "2"),
+					resource.TestCheckResourceAttr(name, "vpc_internal_ips.%", "2"),
 				),
 			},
 		},
@@ -257,6 +890,17 @@ func testAccVultrInstanceBase(name string) string {
 		} `, name)
 }
 
+func testAccVultrInstanceUserData(name, userData string) string {
+	return fmt.Sprintf(`
+		resource "vultr_instance" "test" {
+			plan = "vc2-1c-1gb"
+			region = "sea"
+			os_id = "167"
+			label = "%s"
+			user_data = "%s"
+		} `, name, userData)
+}
+
 func testAccVultrInstanceBaseUpdateFirewall(name string) string {
 	return fmt.Sprintf(`
 		resource "vultr_instance" "test" {
@@ -279,6 +923,50 @@ func testAccVultrInstanceBaseUpdateFirewall(name string) string {
 		`, name)
 }
 
+func testAccVultrInstanceFirewallGroup(name, firewallGroupID string) string {
+	return fmt.Sprintf(`
+		resource "vultr_instance" "test" {
+			plan = "vc2-1c-1gb"
+			region = "sea"
+			os_id = "167"
+			label = "%s"
+			firewall_group_id = %s
+		}
+
+		resource "vultr_firewall_group" "fwg" {
+		  description = "%s-fwg"
+		}
+
+		resource "vultr_firewall_group" "other" {
+		  description = "%s-other"
+		}
+		`, name, firewallGroupID, name, name)
+}
+
+func testAccVultrInstanceRestoreSnapshot(name, snapshotID string) string {
+	return fmt.Sprintf(`
+		resource "vultr_instance" "source" {
+			plan = "vc2-1c-1gb"
+			region = "sea"
+			os_id = "167"
+			label = "%s-source"
+		}
+
+		resource "vultr_snapshot" "source" {
+			instance_id = vultr_instance.source.id
+			description = "%s"
+		}
+
+		resource "vultr_instance" "test" {
+			plan = "vc2-1c-1gb"
+			region = "sea"
+			os_id = "167"
+			label = "%s"
+			restore_snapshot_id = %s
+		}
+		`, name, name, name, snapshotID)
+}
+
 func testAccVultrInstanceBaseUpdateVPCIDs(name string) string {
 	return fmt.Sprintf(`
 	resource "vultr_vpc" "foo" {
@@ -24,6 +24,9 @@ func resourceVultrIsoPrivate() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"url": {
 				Type:     schema.TypeString,
@@ -71,10 +74,10 @@ func resourceVultrIsoCreate(ctx context.Context, d *schema.ResourceData, meta in
 
 	d.SetId(iso.ID)
 
-	_, err = waitForIsoAvailable(ctx, d, "complete", []string{"pending"}, "status", meta)
+	_, err = waitForIsoAvailable(ctx, d, "complete", []string{"pending"}, "status", d.Timeout(schema.TimeoutCreate), meta)
 	if err != nil {
 		return diag.Errorf(
-			"error while waiting for ISO %s detach to be completed: %s", d.Id(), err)
+			"error while waiting for ISO %s download to be completed: %s", d.Id(), err)
 	}
 
 	return resourceVultrIsoRead(ctx, d, meta)
@@ -85,7 +88,7 @@ func resourceVultrIsoRead(ctx context.Context, d *schema.ResourceData, meta inte
 
 	iso, err := client.ISO.Get(ctx, d.Id())
 	if err != nil {
-		if strings.Contains("Invalid iso", err.Error()) {
+		if strings.Contains(err.Error(), "Invalid iso") || strings.Contains(err.Error(), "\"status\":404") {
 			log.Printf("[WARN] Removing ISO (%s) because it is gone", d.Id())
 			d.SetId("")
 			return nil
@@ -166,7 +169,7 @@ func resourceVultrIsoDelete(ctx context.Context, d *schema.ResourceData, meta in
 	return nil
 }
 
-func waitForIsoAvailable(ctx context.Context, d *schema.ResourceData, target string, pending []string, attribute string, meta interface{}) (interface{}, error) {
+func waitForIsoAvailable(ctx context.Context, d *schema.ResourceData, target string, pending []string, attribute string, timeout time.Duration, meta interface{}) (interface{}, error) {
 	log.Printf(
 		"[INFO] Waiting for ISO (%s) to have %s of %s",
 		d.Id(), attribute, target)
@@ -175,7 +178,7 @@ func waitForIsoAvailable(ctx context.Context, d *schema.ResourceData, target str
 		Pending:    pending,
 		Target:     []string{target},
 		Refresh:    newIsoStateRefresh(ctx, d, meta),
-		Timeout:    60 * time.Minute,
+		Timeout:    timeout,
 		Delay:      10 * time.Second,
 		MinTimeout: 3 * time.Second,
 
@@ -2,19 +2,30 @@ package vultr
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vultr/govultr/v2"
 )
 
+// tfVKEDefault is the tag earlier provider versions used to find the default
+// node pool. It is only used to adopt pools that have no ID in state yet.
 var tfVKEDefault = "tf-vke-default"
 
+// vkeKubeConfigTimeout is how long to wait for the kubeconfig of an active
+// cluster to become available.
+const vkeKubeConfigTimeout = 2 * time.Minute
+
 func resourceVultrKubernetes() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceVultrKubernetesCreate,
@@ -22,8 +33,9 @@ func resourceVultrKubernetes() *schema.Resource {
 		UpdateContext: resourceVultrKubernetesUpdate,
 		DeleteContext: resourceVultrKubernetesDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceVultrKubernetesImport,
 		},
+		CustomizeDiff: resourceVultrKubernetesCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"label": {
 				Type:     schema.TypeString,
@@ -33,12 +45,40 @@ func resourceVultrKubernetes() *schema.Resource {
 				Type:     schema.TypeString,
 				ForceNew: true,
 				Required: true,
+				// Region IDs are lowercase, but the casing users paste varies
+				StateFunc: func(v interface{}) string {
+					return strings.ToLower(v.(string))
+				},
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[a-zA-Z0-9]+$`), "must be a region ID such as ewr"),
 			},
 			"version": {
 				Type:     schema.TypeString,
-				ForceNew: true,
 				Required: true,
 			},
+			"include_kube_config": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"kube_config_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"wait_for_endpoint": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"tag": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"ha_controlplanes": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				ForceNew: true,
+			},
 
 			"node_pools": {
 				Type:     schema.TypeList,
@@ -48,37 +88,75 @@ func resourceVultrKubernetes() *schema.Resource {
 					Schema: nodePoolSchema(false),
 				},
 			},
+			// The API assigns the subnets when they are not set
+			"cluster_subnet": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsCIDR,
+			},
+			"service_subnet": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsCIDR,
+			},
 
 			// Computed fields
 			"date_created": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"cluster_subnet": {
+			"ip": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"service_subnet": {
+			"endpoint": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"ip": {
+			"status": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"endpoint": {
+			"firewall_group_id": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
-			"status": {
-				Type:     schema.TypeString,
-				Computed: true,
+			"node_pools_raw": {
+				Description: "JSON of the node pools returned by the API, for debugging only",
+				Type:        schema.TypeString,
+				Computed:    true,
 			},
 			"kube_config": {
 				Description: "Base64 encoded KubeConfig",
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"client_certificate": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"client_key": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+			"cluster_ca_certificate": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
 		},
 	}
 }
@@ -86,45 +164,144 @@ func resourceVultrKubernetes() *schema.Resource {
 func resourceVultrKubernetesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
-	var nodePoolReq []govultr.NodePoolReq
+	var nodePoolReq []vkeNodePoolReq
 	if np, npOk := d.GetOk("node_pools"); npOk {
 		nodePoolReq = generateNodePool(np)
+		for i := range nodePoolReq {
+			nodePoolReq[i].Tag = vkeNodePoolTag(meta.(*Client), nodePoolReq[i].Tag, d.Get("tag").(string))
+		}
 	} else {
 		nodePoolReq = nil
 	}
 
-	req := &govultr.ClusterReq{
-		Label:     d.Get("label").(string),
-		Region:    d.Get("region").(string),
-		Version:   d.Get("version").(string),
-		NodePools: nodePoolReq,
+	req := &vkeClusterReq{
+		Label:           d.Get("label").(string),
+		Region:          d.Get("region").(string),
+		Version:         d.Get("version").(string),
+		HAControlPlanes: d.Get("ha_controlplanes").(bool),
+		ClusterSubnet:   d.Get("cluster_subnet").(string),
+		ServiceSubnet:   d.Get("service_subnet").(string),
+		NodePools:       nodePoolReq,
 	}
 
-	cluster, err := client.Kubernetes.CreateCluster(ctx, req)
+	cluster, err := createVKECluster(ctx, client, req)
 	if err != nil {
 		return diag.Errorf("error creating kubernetes cluster: %v", err)
 	}
 
 	d.SetId(cluster.ID)
 
+	// Persist the default node pool ID so read can find it among other pools
+	if len(cluster.NodePools) != 0 {
+		if err := d.Set("node_pools", flattenNodePool(&cluster.NodePools[0], nil)); err != nil {
+			return diag.Errorf("error setting `node_pools`: %v", err)
+		}
+	}
+
+	// A cluster can be active a moment before its endpoint is assigned, so
+	// optionally wait for the endpoint as well
+	waitFor := "status"
+	if d.Get("wait_for_endpoint").(bool) {
+		waitFor = "endpoint"
+	}
+
 	//block until status is ready
-	if _, err = waitForVKEAvailable(ctx, d, "active", []string{"pending"}, "status", meta); err != nil {
+	if _, err = waitForVKEAvailable(ctx, d, "active", []string{"pending"}, waitFor, d.Timeout(schema.TimeoutCreate), meta); err != nil {
 		return diag.Errorf(
 			"error while waiting for kubernetes cluster %v to be completed: %v", cluster.ID, err)
 	}
 
-	return resourceVultrKubernetesRead(ctx, d, meta)
+	// The cluster reports active before the nodes of its pool are schedulable
+	if len(cluster.NodePools) != 0 {
+		nodePoolID := cluster.NodePools[0].ID
+		if _, err := waitForNodePoolReady(ctx, client, d.Id(), nodePoolID, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.Errorf("error while waiting for VKE node pool %v to be ready: %v", nodePoolID, err)
+		}
+	}
+
+	if diags := resourceVultrKubernetesRead(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+
+	return writeVKEKubeConfigPath(d)
+}
+
+// resourceVultrKubernetesCustomizeDiff rejects settings that the API would
+// only reject once the cluster is created.
+func resourceVultrKubernetesCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// VKE requires a node pool to create a cluster. It can be removed later
+	// once other pools are managed by vultr_kubernetes_node_pools.
+	if _, ok := d.GetOk("node_pools"); !ok && d.Id() == "" {
+		return fmt.Errorf("node_pools must contain a node pool when creating a kubernetes cluster")
+	}
+
+	// Creating a cluster in a region without VKE only fails once the API
+	// rejects it, so check the region up front.
+	if d.Id() == "" && d.NewValueKnown("region") {
+		region, err := getRegion(ctx, meta.(*Client).govultrClient(), strings.ToLower(d.Get("region").(string)))
+		if err != nil {
+			return err
+		}
+
+		if !regionHasOption(region, "kubernetes") {
+			return fmt.Errorf("VKE is not available in region %s", region.ID)
+		}
+	}
+
+	if d.Get("kube_config_path").(string) != "" && !d.Get("include_kube_config").(bool) {
+		return fmt.Errorf("kube_config_path requires include_kube_config to be true")
+	}
+
+	// Upgrades can rotate the cluster CA and endpoint. Marking the credentials
+	// unknown lets providers configured from them wait for the new values
+	// instead of using the ones cached in state.
+	if d.Id() != "" && d.HasChange("version") && d.Get("include_kube_config").(bool) {
+		for _, key := range []string{"endpoint", "kube_config", "host", "client_certificate", "client_key", "cluster_ca_certificate"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, ok := d.GetOk("node_pools.0"); ok {
+		if err := checkNodePoolBootstrap(d.Get("node_pools.0.script_id").(string), d.Get("node_pools.0.user_data").(string)); err != nil {
+			return err
+		}
+
+		oldAutoScaler, newAutoScaler := d.GetChange("node_pools.0.auto_scaler")
+		if d.Get("node_pools.0.id").(string) != "" && !oldAutoScaler.(bool) && newAutoScaler.(bool) {
+			minSet, maxSet := false, false
+			if pools := d.GetRawConfig().GetAttr("node_pools"); pools.IsKnown() && !pools.IsNull() && pools.LengthInt() != 0 {
+				pool := pools.AsValueSlice()[0]
+				minSet, maxSet = !pool.GetAttr("min_nodes").IsNull(), !pool.GetAttr("max_nodes").IsNull()
+			}
+			if err := validateNodePoolAutoScalerEnable(minSet, maxSet); err != nil {
+				return err
+			}
+		}
+
+		if err := validateNodePoolAutoScaler(
+			d.Get("node_pools.0.auto_scaler").(bool),
+			d.Get("node_pools.0.min_nodes").(int),
+			d.Get("node_pools.0.max_nodes").(int),
+			d.Get("node_pools.0.node_quantity").(int),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func resourceVultrKubernetesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
-	vke, err := client.Kubernetes.GetCluster(ctx, d.Id())
+	vke, err := getVKECluster(ctx, client, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "Unauthorized") {
+		if isVKEUnauthorized(err) {
 			return diag.Errorf("API authorization error: %v", err)
 		}
-		if strings.Contains(err.Error(), "Invalid resource ID") {
+		if isVKENotFound(err) {
 			log.Printf("[WARN] Kubernetes Cluster (%v) not found", d.Id())
 			d.SetId("")
 			return nil
@@ -132,33 +309,71 @@ func resourceVultrKubernetesRead(ctx context.Context, d *schema.ResourceData, me
 		return diag.Errorf("error getting cluster (%s): %v", d.Id(), err)
 	}
 
-	// Look for the node pool with the tag `tf-vke-default`
-	for _, v := range vke.NodePools {
-		if tfVKEDefault == v.Tag {
-			if err := d.Set("node_pools", flattenNodePool(&v)); err != nil {
-				return diag.Errorf("error setting `node_pool`: %v", err)
-			}
-			break
+	poolID := d.Get("node_pools.0.id").(string)
+	if np := findVKEDefaultNodePool(vke.NodePools, poolID); np != nil {
+		pools := flattenNodePool(np, nodeMainIPs(ctx, client, np.Tag, np.Nodes))
+		if err := d.Set("node_pools", pools); err != nil {
+			return diag.Errorf("error setting `node_pool`: %v", err)
 		}
+	} else if poolID != "" {
+		log.Printf("[WARN] Kubernetes Cluster (%v) node pool (%v) not found", d.Id(), poolID)
+		d.Set("node_pools", nil)
 	}
 
+	d.Set("version", vke.Version)
 	d.Set("date_created", vke.DateCreated)
+	d.Set("ha_controlplanes", vke.HAControlPlanes)
 	d.Set("cluster_subnet", vke.ClusterSubnet)
 	d.Set("service_subnet", vke.ServiceSubnet)
 	d.Set("ip", vke.IP)
 	d.Set("endpoint", vke.Endpoint)
 	d.Set("status", vke.Status)
+	d.Set("firewall_group_id", vke.FirewallGroupID)
 
-	config, err := client.Kubernetes.GetKubeConfig(ctx, d.Id())
+	// Every pool is included, not just the one in node_pools, to help debug
+	// which pool the default pool lookup picked.
+	nodePoolsRaw, err := json.Marshal(vke.NodePools)
 	if err != nil {
-		return diag.Errorf("could not get kubeconfig : %v", err)
+		return diag.Errorf("error serializing node pools for cluster (%s): %v", d.Id(), err)
 	}
+	d.Set("node_pools_raw", string(nodePoolsRaw))
 
-	d.Set("kube_config", config.KubeConfig)
+	if !d.Get("include_kube_config").(bool) {
+		for _, key := range []string{"kube_config", "host", "client_certificate", "client_key", "cluster_ca_certificate"} {
+			d.Set(key, "")
+		}
+		return nil
+	}
+
+	kubeConfig, available, err := readVKEKubeConfig(ctx, client, d.Id(), vke.Status)
+	if err != nil {
+		return diag.Errorf("could not get kubeconfig for cluster (%s): %v", d.Id(), err)
+	}
+
+	// The kubeconfig is briefly unavailable while the control plane is being
+	// replaced, so the last known credentials are kept until it is back. It
+	// may also not be populated yet on a cluster that is still pending.
+	if available {
+		d.Set("kube_config", kubeConfig)
+	}
+	if available && kubeConfig != "" {
+		creds, err := parseKubeConfig(kubeConfig)
+		if err != nil {
+			return diag.Errorf("error parsing kubeconfig for cluster (%s): %v", d.Id(), err)
+		}
+
+		d.Set("host", creds.Host)
+		d.Set("client_certificate", creds.ClientCertificate)
+		d.Set("client_key", creds.ClientKey)
+		d.Set("cluster_ca_certificate", creds.ClusterCACertificate)
+	}
 
 	return nil
 }
 
+// resourceVultrKubernetesUpdate applies changes in a fixed order: label,
+// version, then node pools. A failure part way through refreshes state so it
+// keeps the changes that were already made.
 func resourceVultrKubernetesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
@@ -167,58 +382,99 @@ func resourceVultrKubernetesUpdate(ctx context.Context, d *schema.ResourceData,
 		req.Label = d.Get("label").(string)
 
 		if err := client.Kubernetes.UpdateCluster(ctx, d.Id(), req); err != nil {
-			return diag.Errorf("error updating vke cluster (%v): %v", d.Id(), err)
+			if isVKENotFound(err) {
+				return removeMissingVKE(d)
+			}
+			return vkeUpdateFailed(ctx, d, meta, diag.Errorf("error updating vke cluster (%v): %v", d.Id(), err))
 		}
 	}
 
-	if d.HasChange("node_pools") {
+	if d.HasChange("version") {
+		oldVersion, newVersion := d.GetChange("version")
 
-		oldNP, newNP := d.GetChange("node_pools")
-
-		if len(newNP.([]interface{})) != 0 && len(oldNP.([]interface{})) != 0 {
+		newer, err := isNewerVKEVersion(oldVersion.(string), newVersion.(string))
+		if err != nil {
+			return diag.Errorf("error comparing vke versions: %v", err)
+		}
+		if !newer {
+			return diag.Errorf("cannot change vke cluster (%v) version from %v to %v : downgrades are not supported", d.Id(), oldVersion, newVersion)
+		}
 
-			n := newNP.([]interface{})[0].(map[string]interface{})
+		log.Printf("[INFO] Upgrading VKE cluster (%v) to %v", d.Id(), newVersion)
+		req := &govultr.ClusterUpgradeReq{
+			UpgradeVersion: newVersion.(string),
+		}
 
-			req := &govultr.NodePoolReqUpdate{
-				NodeQuantity: n["node_quantity"].(int),
-				AutoScaler:   govultr.BoolToBoolPtr(n["auto_scaler"].(bool)),
-				MinNodes:     n["min_nodes"].(int),
-				MaxNodes:     n["max_nodes"].(int),
-				// Not updating tag for default node pool since it's needed to lookup in terraform
+		if err := client.Kubernetes.Upgrade(ctx, d.Id(), req); err != nil {
+			if isVKENotFound(err) {
+				return removeMissingVKE(d)
 			}
+			return vkeUpdateFailed(ctx, d, meta, diag.Errorf("error upgrading vke cluster (%v): %v", d.Id(), err))
+		}
 
-			if _, err := client.Kubernetes.UpdateNodePool(ctx, d.Id(), n["id"].(string), req); err != nil {
-				return diag.Errorf("error updating VKE node pool %v : %v", d.Id(), err)
-			}
-		} else if len(newNP.([]interface{})) == 0 && len(oldNP.([]interface{})) != 0 {
-			// if we have an old node pool state but don't have a new node pool state
-			// we can safely assume this is a node pool removal
+		if _, err := waitForVKEAvailable(ctx, d, "active", []string{"pending"}, "status", d.Timeout(schema.TimeoutUpdate), meta); err != nil {
+			return vkeUpdateFailed(ctx, d, meta, diag.Errorf("error while waiting for kubernetes cluster %v to be upgraded: %v", d.Id(), err))
+		}
+	}
+
+	// The API has no cluster tags, so the cluster tag is applied to the node
+	// pool unless the pool sets its own.
+	poolTagSet := isVKENodePoolTagSet(d)
+	clusterTag := d.Get("tag").(string)
+
+	// node_pools holds a single pool, so its changes run one at a time. Other
+	// pools are managed by vultr_kubernetes_node_pools, which terraform already
+	// applies in parallel, bounded by -parallelism.
+	if d.HasChange("node_pools") || (d.HasChange("tag") && !poolTagSet) {
+		oldNP, newNP := d.GetChange("node_pools")
+		if newPools := newNP.([]interface{}); len(newPools) != 0 && !poolTagSet && (clusterTag != "" || d.HasChange("tag")) {
+			// Like on create, a removed cluster tag falls back to the
+			// provider default_tag instead of clearing the pool tag
+			newPools[0].(map[string]interface{})["tag"] = vkeNodePoolTag(meta.(*Client), "", clusterTag)
+		}
 
-			n := oldNP.([]interface{})[0].(map[string]interface{})
+		// The API cannot change the plan of a pool, so the pool is replaced:
+		// the new pool is created and ready before the old one is deleted.
+		oldPools := oldNP.([]interface{})
+		replace := isVKENodePoolReplace(oldPools, newNP.([]interface{}))
+		if replace {
+			log.Printf("[INFO] Replacing VKE node pool %v to change its plan", d.Get("node_pools.0.id"))
+			oldPools = []interface{}{}
+		}
 
-			if err := client.Kubernetes.DeleteNodePool(ctx, d.Id(), n["id"].(string)); err != nil {
-				return diag.Errorf("error deleting VKE node pool %v : %v", d.Id(), err)
+		nodePoolID, err := applyVKENodePoolChange(ctx, meta.(*Client), d.Id(), oldPools, newNP.([]interface{}))
+		if err != nil {
+			if isVKENotFound(err) {
+				return removeMissingVKE(d)
 			}
-		} else if len(newNP.([]interface{})) != 0 && len(oldNP.([]interface{})) == 0 {
-			// if we don't have an old node pool state but have a new node pool state
-			// we can safely assume this is a new node pool creation
+			return vkeUpdateFailed(ctx, d, meta, diag.FromErr(err))
+		}
 
-			n := newNP.([]interface{})[0].(map[string]interface{})
+		if nodePoolID != "" {
+			np, err := waitForNodePoolReady(ctx, client, d.Id(), nodePoolID, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return vkeUpdateFailed(ctx, d, meta, diag.Errorf("error while waiting for VKE node pool %v to be ready: %v", nodePoolID, err))
+			}
 
-			req := &govultr.NodePoolReq{
-				NodeQuantity: n["node_quantity"].(int),
-				Tag:          tfVKEDefault,
-				Plan:         n["plan"].(string),
-				Label:        n["label"].(string),
+			// Track the pool by its ID so read finds a new pool among the others
+			if err := d.Set("node_pools", flattenNodePool(np.(*vkeNodePool), nil)); err != nil {
+				return diag.Errorf("error setting `node_pools`: %v", err)
 			}
+		}
 
-			if _, err := client.Kubernetes.CreateNodePool(ctx, d.Id(), req); err != nil {
-				return diag.Errorf("error creating VKE node pool %v : %v", d.Id(), err)
+		if replace {
+			if _, err := applyVKENodePoolChange(ctx, meta.(*Client), d.Id(), oldNP.([]interface{}), []interface{}{}); err != nil {
+				return vkeUpdateFailed(ctx, d, meta, diag.Errorf("the node pool was replaced but the old pool could not be removed, delete it manually: %v", err))
 			}
 		}
 	}
 
-	return resourceVultrKubernetesRead(ctx, d, meta)
+	if diags := resourceVultrKubernetesRead(ctx, d, meta); diags.HasError() {
+		return diags
+	}
+
+	// Upgrades rotate the credentials and kube_config_path may have moved
+	return writeVKEKubeConfigPath(d)
 }
 
 func resourceVultrKubernetesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -227,25 +483,346 @@ func resourceVultrKubernetesDelete(ctx context.Context, d *schema.ResourceData,
 	log.Printf("[INFO] Delete VKE : %v", d.Id())
 
 	if err := client.Kubernetes.DeleteCluster(ctx, d.Id()); err != nil {
+		if isVKENotFound(err) {
+			return nil
+		}
 		return diag.Errorf("error deleting VKE %v : %v", d.Id(), err)
 	}
+
+	// The cluster keeps references to resources such as its VPC while it is
+	// being deleted, so wait for it to be gone before those are destroyed
+	if err := waitForVKEDeleted(ctx, client, d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.Errorf("error while waiting for VKE %v to be deleted: %v", d.Id(), err)
+	}
+
 	return nil
 }
 
-func generateNodePool(pools interface{}) []govultr.NodePoolReq {
-	var npr []govultr.NodePoolReq
+// waitForVKEDeleted polls the cluster until the API no longer returns it.
+func waitForVKEDeleted(ctx context.Context, client *govultr.Client, clusterID string, timeout time.Duration) error {
+	log.Printf("[INFO] Waiting for kubernetes cluster (%s) to be deleted", clusterID)
+
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		if _, err := client.Kubernetes.GetCluster(ctx, clusterID); err != nil {
+			if isVKENotFound(err) {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+		return resource.RetryableError(fmt.Errorf("kubernetes cluster %s still exists", clusterID))
+	})
+}
+
+// resourceVultrKubernetesImport accepts either a cluster ID or
+// "label:<name>", in which case the cluster with that label is looked up.
+func resourceVultrKubernetesImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// Defaults are not applied to imported state
+	d.Set("include_kube_config", true)
+	d.Set("wait_for_endpoint", true)
+
+	importID := d.Id()
+	if !strings.HasPrefix(importID, "label:") {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	client := meta.(*Client).govultrClient()
+	label := strings.TrimPrefix(importID, "label:")
+	if label == "" {
+		return nil, fmt.Errorf(`invalid import format, expected "label:<name>"`)
+	}
+
+	var ids []string
+	options := &govultr.ListOptions{}
+	for {
+		clusters, listMeta, err := client.Kubernetes.ListClusters(ctx, options)
+		if err != nil {
+			return nil, fmt.Errorf("error getting list of VKE clusters: %v", err)
+		}
+
+		for i := range clusters {
+			if clusters[i].Label == label {
+				ids = append(ids, clusters[i].ID)
+			}
+		}
+
+		if listMeta == nil || listMeta.Links == nil || listMeta.Links.Next == "" {
+			break
+		}
+		options.Cursor = listMeta.Links.Next
+	}
+
+	switch len(ids) {
+	case 0:
+		return nil, fmt.Errorf("no VKE cluster found with label %q", label)
+	case 1:
+		d.SetId(ids[0])
+		return []*schema.ResourceData{d}, nil
+	default:
+		return nil, fmt.Errorf("%d VKE clusters found with label %q, import by ID instead: %s", len(ids), label, strings.Join(ids, ", "))
+	}
+}
+
+// vkeCluster is a VKE cluster including the fields that govultr.Cluster does
+// not decode.
+type vkeCluster struct {
+	govultr.Cluster
+	FirewallGroupID string        `json:"firewall_group_id"`
+	HAControlPlanes bool          `json:"ha_controlplanes"`
+	NodePools       []vkeNodePool `json:"node_pools"`
+}
+
+// vkeClusterReq is a cluster create request including the fields that
+// govultr.ClusterReq and govultr.NodePoolReq have no fields for.
+type vkeClusterReq struct {
+	Label           string           `json:"label"`
+	Region          string           `json:"region"`
+	Version         string           `json:"version"`
+	HAControlPlanes bool             `json:"ha_controlplanes"`
+	ClusterSubnet   string           `json:"cluster_subnet,omitempty"`
+	ServiceSubnet   string           `json:"service_subnet,omitempty"`
+	NodePools       []vkeNodePoolReq `json:"node_pools"`
+}
+
+// createVKECluster creates a cluster directly so that the fields of
+// vkeClusterReq are sent.
+func createVKECluster(ctx context.Context, client *govultr.Client, createReq *vkeClusterReq) (*vkeCluster, error) {
+	req, err := client.NewRequest(ctx, http.MethodPost, "/v2/kubernetes/clusters", createReq)
+	if err != nil {
+		return nil, err
+	}
+
+	var cluster struct {
+		VKECluster *vkeCluster `json:"vke_cluster"`
+	}
+	if err := client.DoWithContext(ctx, req, &cluster); err != nil {
+		return nil, err
+	}
+
+	if cluster.VKECluster == nil {
+		return nil, fmt.Errorf("cluster was not returned by the API")
+	}
+
+	return cluster.VKECluster, nil
+}
+
+// getVKECluster fetches a cluster directly so that the firewall group the API
+// creates for the cluster nodes is available.
+func getVKECluster(ctx context.Context, client *govultr.Client, clusterID string) (*vkeCluster, error) {
+	req, err := client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("/v2/kubernetes/clusters/%s", clusterID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var cluster struct {
+		VKECluster *vkeCluster `json:"vke_cluster"`
+	}
+	if err := client.DoWithContext(ctx, req, &cluster); err != nil {
+		return nil, err
+	}
+
+	if cluster.VKECluster == nil {
+		return nil, fmt.Errorf("cluster %s was not returned by the API", clusterID)
+	}
+
+	return cluster.VKECluster, nil
+}
+
+// getVKEKubeConfig polls the API until the cluster returns a non-empty
+// kubeconfig or the timeout expires. Only an empty kubeconfig is retried,
+// govultr already retries rate limits and server errors, so any error it
+// returns, e.g. for a revoked API key or a deleted cluster, is final.
+func getVKEKubeConfig(ctx context.Context, client *govultr.Client, clusterID string, timeout time.Duration) (string, error) {
+	var kubeConfig string
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		config, err := client.Kubernetes.GetKubeConfig(ctx, clusterID)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		if config.KubeConfig == "" {
+			return resource.RetryableError(fmt.Errorf("kubeconfig for cluster %s is not available yet", clusterID))
+		}
+
+		kubeConfig = config.KubeConfig
+		return nil
+	})
+
+	return kubeConfig, err
+}
+
+// readVKEKubeConfig returns the kubeconfig of the cluster and whether it is
+// available. A cluster that is not active may not serve one, which is only
+// an error when the API rejected the credentials.
+func readVKEKubeConfig(ctx context.Context, client *govultr.Client, clusterID, status string) (string, bool, error) {
+	if status == "active" {
+		// Fresh and freshly upgraded clusters can report active shortly
+		// before the kubeconfig is available again
+		kubeConfig, err := getVKEKubeConfig(ctx, client, clusterID, vkeKubeConfigTimeout)
+		if err != nil {
+			return "", false, err
+		}
+		return kubeConfig, true, nil
+	}
+
+	config, err := client.Kubernetes.GetKubeConfig(ctx, clusterID)
+	if err != nil {
+		if isVKEUnauthorized(err) {
+			return "", false, err
+		}
+		log.Printf("[WARN] could not get kubeconfig for %s cluster (%s), keeping the current one: %v", status, clusterID, err)
+		return "", false, nil
+	}
+
+	return config.KubeConfig, true, nil
+}
+
+// isVKEUnauthorized reports whether err is the API rejecting the API key,
+// which retrying or waiting for the cluster can't fix.
+func isVKEUnauthorized(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "Unauthorized") || strings.Contains(msg, "\"status\":401") || strings.Contains(msg, "\"status\":403")
+}
+
+// isVKENotFound reports whether err is the API response for a cluster that no
+// longer exists.
+func isVKENotFound(err error) bool {
+	return strings.Contains(err.Error(), "Invalid resource ID") || strings.Contains(err.Error(), "\"status\":404")
+}
+
+// applyVKENodePoolChange updates, deletes or creates the cluster node pool
+// depending on whether it is present in the old and new state. It returns the
+// ID of the node pool to wait for, which is empty when the pool was deleted.
+func applyVKENodePoolChange(ctx context.Context, meta *Client, clusterID string, oldNP, newNP []interface{}) (string, error) {
+	client := meta.govultrClient()
+
+	switch {
+	case len(newNP) != 0 && len(oldNP) != 0:
+		n := newNP[0].(map[string]interface{})
+
+		req := nodePoolUpdateReq(
+			n["node_quantity"].(int),
+			meta.tagOrDefault(n["tag"].(string)),
+			n["auto_scaler"].(bool),
+			n["min_nodes"].(int),
+			n["max_nodes"].(int),
+			n["labels"].(map[string]interface{}),
+			n["taints"].(*schema.Set),
+		)
+
+		if _, err := updateVKENodePool(ctx, client, clusterID, n["id"].(string), req); err != nil {
+			return "", fmt.Errorf("error updating VKE node pool %v : %v", clusterID, err)
+		}
+		return n["id"].(string), nil
+	case len(newNP) == 0 && len(oldNP) != 0:
+		// if we have an old node pool state but don't have a new node pool state
+		// we can safely assume this is a node pool removal
+		n := oldNP[0].(map[string]interface{})
+
+		if err := client.Kubernetes.DeleteNodePool(ctx, clusterID, n["id"].(string)); err != nil {
+			return "", fmt.Errorf("error deleting VKE node pool %v : %v", clusterID, err)
+		}
+		return "", nil
+	case len(newNP) != 0 && len(oldNP) == 0:
+		// if we don't have an old node pool state but have a new node pool state
+		// we can safely assume this is a new node pool creation
+		n := newNP[0].(map[string]interface{})
+
+		req := generateNodePool([]interface{}{n})[0]
+		req.Tag = meta.tagOrDefault(req.Tag)
+
+		nodePool, err := createVKENodePool(ctx, client, clusterID, &req)
+		if err != nil {
+			return "", fmt.Errorf("error creating VKE node pool %v : %v", clusterID, err)
+		}
+		return nodePool.ID, nil
+	}
+
+	return "", nil
+}
+
+// isVKENodePoolReplace reports whether the node pool change needs a new pool,
+// which is the case when its plan changes.
+func isVKENodePoolReplace(oldNP, newNP []interface{}) bool {
+	if len(oldNP) == 0 || len(newNP) == 0 {
+		return false
+	}
+
+	return oldNP[0].(map[string]interface{})["plan"] != newNP[0].(map[string]interface{})["plan"]
+}
+
+// vkeUpdateFailed reads the cluster back after a failed update so state
+// reflects what was applied instead of the planned values.
+func vkeUpdateFailed(ctx context.Context, d *schema.ResourceData, meta interface{}, diags diag.Diagnostics) diag.Diagnostics {
+	return append(diags, resourceVultrKubernetesRead(ctx, d, meta)...)
+}
+
+// writeVKEKubeConfigPath writes the kubeconfig read into state to
+// kube_config_path. It only runs during apply so that refreshing or planning
+// never touches the local file.
+func writeVKEKubeConfigPath(d *schema.ResourceData) diag.Diagnostics {
+	path, kubeConfig := d.Get("kube_config_path").(string), d.Get("kube_config").(string)
+	if path == "" || kubeConfig == "" {
+		return nil
+	}
+
+	if err := writeKubeConfig(path, kubeConfig); err != nil {
+		return diag.Errorf("error writing kubeconfig for cluster (%s): %v", d.Id(), err)
+	}
+
+	return nil
+}
+
+// removeMissingVKE drops a cluster that was deleted outside of terraform from
+// state so the next plan recreates it.
+func removeMissingVKE(d *schema.ResourceData) diag.Diagnostics {
+	log.Printf("[WARN] Kubernetes Cluster (%v) not found, removing from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+// vkeNodePoolTag returns the tag for a node pool created with the cluster. A
+// pool without its own tag gets the cluster tag, then the provider default_tag.
+func vkeNodePoolTag(meta *Client, poolTag, clusterTag string) string {
+	if poolTag == "" {
+		poolTag = clusterTag
+	}
+	return meta.tagOrDefault(poolTag)
+}
+
+// isVKENodePoolTagSet reports whether the cluster node pool has a tag in
+// config. The pool tag is computed, so its value in state can't tell.
+func isVKENodePoolTagSet(d *schema.ResourceData) bool {
+	config := d.GetRawConfig()
+	if !config.IsKnown() || config.IsNull() {
+		return false
+	}
+
+	pools := config.GetAttr("node_pools")
+	if !pools.IsKnown() || pools.IsNull() || pools.LengthInt() == 0 {
+		return false
+	}
+
+	return !pools.AsValueSlice()[0].GetAttr("tag").IsNull()
+}
+
+func generateNodePool(pools interface{}) []vkeNodePoolReq {
+	var npr []vkeNodePoolReq
 	pool := pools.([]interface{})
 	for _, p := range pool {
 		r := p.(map[string]interface{})
 
-		t := govultr.NodePoolReq{
-			NodeQuantity: r["node_quantity"].(int),
-			Label:        r["label"].(string),
-			Plan:         r["plan"].(string),
-			Tag:          tfVKEDefault,
-			AutoScaler:   govultr.BoolToBoolPtr(r["auto_scaler"].(bool)),
-			MinNodes:     r["min_nodes"].(int),
-			MaxNodes:     r["max_nodes"].(int),
+		t := vkeNodePoolReq{
+			NodePoolReq: govultr.NodePoolReq{
+				NodeQuantity: r["node_quantity"].(int),
+				Label:        r["label"].(string),
+				Plan:         r["plan"].(string),
+				Tag:          r["tag"].(string),
+				AutoScaler:   govultr.BoolToBoolPtr(r["auto_scaler"].(bool)),
+				MinNodes:     r["min_nodes"].(int),
+				MaxNodes:     r["max_nodes"].(int),
+			},
+			Labels: expandNodePoolLabels(r["labels"].(map[string]interface{})),
+			Taints: expandNodePoolTaints(r["taints"].(*schema.Set)),
 		}
 
 		npr = append(npr, t)
@@ -253,7 +830,33 @@ func generateNodePool(pools interface{}) []govultr.NodePoolReq {
 	return npr
 }
 
-func waitForVKEAvailable(ctx context.Context, d *schema.ResourceData, target string, pending []string, attribute string, meta interface{}) (interface{}, error) {
+// findVKEDefaultNodePool returns the node pool managed by the vultr_kubernetes
+// resource. Pools are matched on the ID stored in state, falling back to the
+// legacy tag or a lone pool when no ID is known yet, e.g. after an import.
+func findVKEDefaultNodePool(pools []vkeNodePool, id string) *vkeNodePool {
+	if id != "" {
+		for i := range pools {
+			if pools[i].ID == id {
+				return &pools[i]
+			}
+		}
+		return nil
+	}
+
+	for i := range pools {
+		if pools[i].Tag == tfVKEDefault {
+			return &pools[i]
+		}
+	}
+
+	if len(pools) == 1 {
+		return &pools[0]
+	}
+
+	return nil
+}
+
+func waitForVKEAvailable(ctx context.Context, d *schema.ResourceData, target string, pending []string, attribute string, timeout time.Duration, meta interface{}) (interface{}, error) {
 	log.Printf(
 		"[INFO] Waiting for kubernetes cluster (%s) to have %s of %s",
 		d.Id(), attribute, target)
@@ -262,7 +865,7 @@ func waitForVKEAvailable(ctx context.Context, d *schema.ResourceData, target str
 		Pending:        pending,
 		Target:         []string{target},
 		Refresh:        newVKEStateRefresh(ctx, d, meta, attribute),
-		Timeout:        60 * time.Minute,
+		Timeout:        timeout,
 		Delay:          10 * time.Second,
 		MinTimeout:     5 * time.Second,
 		NotFoundChecks: 60,
@@ -279,19 +882,64 @@ func newVKEStateRefresh(ctx context.Context, d *schema.ResourceData, meta interf
 
 		vke, err := client.Kubernetes.GetCluster(ctx, d.Id())
 		if err != nil {
-			return nil, "", fmt.Errorf("error retrieving kubernetes cluster %s ", d.Id())
+			return nil, "", fmt.Errorf("error retrieving kubernetes cluster %s: %v", d.Id(), err)
 		}
 
-		if attr == "status" {
+		// A failed cluster never becomes active, so stop waiting right away
+		if isVKEFailedStatus(vke.Status) {
+			return vke, vke.Status, fmt.Errorf("kubernetes cluster %s has status %s%s", d.Id(), vke.Status, vkeFailureDetail(vke))
+		}
+
+		switch attr {
+		case "status":
 			log.Printf("[INFO] The kubernetes cluster Status is %v", vke.Status)
 			return vke, vke.Status, nil
+		case "endpoint":
+			if vke.Status != "active" || vke.Endpoint == "" || vke.IP == "" {
+				log.Printf("[INFO] The kubernetes cluster Status is %v, endpoint %q and IP %q", vke.Status, vke.Endpoint, vke.IP)
+				return vke, "pending", nil
+			}
+			return vke, vke.Status, nil
 		}
 
 		return nil, "", nil
 	}
 }
 
-func flattenNodePool(np *govultr.NodePool) []map[string]interface{} {
+// vkeFailedStatuses are the cluster statuses the API does not recover from.
+var vkeFailedStatuses = []string{"error", "failed"}
+
+func isVKEFailedStatus(status string) bool {
+	for _, s := range vkeFailedStatuses {
+		if strings.EqualFold(status, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// vkeFailureDetail lists the node pools and nodes of a failed cluster that
+// are not active, since the API does not return a reason for the failure.
+func vkeFailureDetail(vke *govultr.Cluster) string {
+	var detail []string
+	for _, np := range vke.NodePools {
+		if np.Status != "active" {
+			detail = append(detail, fmt.Sprintf("node pool %s is %s", np.ID, np.Status))
+		}
+		for _, n := range np.Nodes {
+			if n.Status != "active" {
+				detail = append(detail, fmt.Sprintf("node %s is %s", n.ID, n.Status))
+			}
+		}
+	}
+
+	if len(detail) == 0 {
+		return ""
+	}
+	return ": " + strings.Join(detail, ", ")
+}
+
+func flattenNodePool(np *vkeNodePool, ips map[string]string) []map[string]interface{} {
 	var nodePools []map[string]interface{}
 
 	var instances []map[string]interface{}
@@ -301,6 +949,9 @@ func flattenNodePool(np *govultr.NodePool) []map[string]interface{} {
 			"status":       v.Status,
 			"date_created": v.DateCreated,
 			"label":        v.Label,
+			"main_ip":      ips[v.ID],
+			// Every node runs on an instance that shares its ID
+			"instance_id": v.ID,
 		}
 		instances = append(instances, n)
 	}
@@ -313,14 +964,64 @@ func flattenNodePool(np *govultr.NodePool) []map[string]interface{} {
 		"date_created":  np.DateCreated,
 		"date_updated":  np.DateUpdated,
 		"status":        np.Status,
+		"ready":         isNodePoolReady(&np.NodePool),
 		"tag":           np.Tag,
 		"nodes":         instances,
 		"auto_scaler":   np.AutoScaler,
 		"min_nodes":     np.MinNodes,
 		"max_nodes":     np.MaxNodes,
+		"labels":        np.Labels,
+		"taints":        flattenNodePoolTaints(np.Taints),
 	}
 
 	nodePools = append(nodePools, pool)
 
 	return nodePools
 }
+
+// isNewerVKEVersion reports whether target is a later VKE version than current.
+// Versions are in the form v1.24.3+2 where the trailing number is the Vultr build.
+func isNewerVKEVersion(current, target string) (bool, error) {
+	c, err := parseVKEVersion(current)
+	if err != nil {
+		return false, err
+	}
+
+	t, err := parseVKEVersion(target)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range c {
+		if t[i] != c[i] {
+			return t[i] > c[i], nil
+		}
+	}
+
+	return false, nil
+}
+
+func parseVKEVersion(version string) ([4]int, error) {
+	var parsed [4]int
+
+	v := strings.TrimPrefix(version, "v")
+	build := "0"
+	if plusIdx := strings.IndexByte(v, '+'); plusIdx != -1 {
+		v, build = v[:plusIdx], v[plusIdx+1:]
+	}
+
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return parsed, fmt.Errorf("invalid version %q", version)
+	}
+
+	for i, p := range append(parts, build) {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return parsed, fmt.Errorf("invalid version %q", version)
+		}
+		parsed[i] = n
+	}
+
+	return parsed, nil
+}
@@ -19,26 +19,61 @@ func resourceVultrKubernetesNodePools() *schema.Resource {
 		ReadContext:   resourceVultrKubernetesNodePoolsRead,
 		UpdateContext: resourceVultrKubernetesNodePoolsUpdate,
 		DeleteContext: resourceVultrKubernetesNodePoolsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVultrKubernetesNodePoolsImport,
+		},
+		CustomizeDiff: resourceVultrKubernetesNodePoolsCustomizeDiff,
 		Schema:        nodePoolSchema(true),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+		},
 	}
 }
 
+func resourceVultrKubernetesNodePoolsCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := checkNodePoolBootstrap(d.Get("script_id").(string), d.Get("user_data").(string)); err != nil {
+		return err
+	}
+
+	if d.Id() != "" && d.HasChange("auto_scaler") && d.Get("auto_scaler").(bool) {
+		minSet, maxSet := true, true
+		if raw := d.GetRawConfig(); raw.IsKnown() && !raw.IsNull() {
+			minSet, maxSet = !raw.GetAttr("min_nodes").IsNull(), !raw.GetAttr("max_nodes").IsNull()
+		}
+		if err := validateNodePoolAutoScalerEnable(minSet, maxSet); err != nil {
+			return err
+		}
+	}
+
+	return validateNodePoolAutoScaler(
+		d.Get("auto_scaler").(bool),
+		d.Get("min_nodes").(int),
+		d.Get("max_nodes").(int),
+		d.Get("node_quantity").(int),
+	)
+}
+
 func resourceVultrKubernetesNodePoolsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
 	clusterID := d.Get("cluster_id").(string)
 
-	req := &govultr.NodePoolReq{
-		NodeQuantity: d.Get("node_quantity").(int),
-		Label:        d.Get("label").(string),
-		Plan:         d.Get("plan").(string),
-		Tag:          d.Get("tag").(string),
-		AutoScaler:   govultr.BoolToBoolPtr(d.Get("auto_scaler").(bool)),
-		MinNodes:     d.Get("min_nodes").(int),
-		MaxNodes:     d.Get("max_nodes").(int),
+	req := &vkeNodePoolReq{
+		NodePoolReq: govultr.NodePoolReq{
+			NodeQuantity: d.Get("node_quantity").(int),
+			Label:        d.Get("label").(string),
+			Plan:         d.Get("plan").(string),
+			Tag:          meta.(*Client).tagOrDefault(d.Get("tag").(string)),
+			AutoScaler:   govultr.BoolToBoolPtr(d.Get("auto_scaler").(bool)),
+			MinNodes:     d.Get("min_nodes").(int),
+			MaxNodes:     d.Get("max_nodes").(int),
+		},
+		Labels: expandNodePoolLabels(d.Get("labels").(map[string]interface{})),
+		Taints: expandNodePoolTaints(d.Get("taints").(*schema.Set)),
 	}
 
-	nodePool, err := client.Kubernetes.CreateNodePool(ctx, clusterID, req)
+	nodePool, err := createVKENodePool(ctx, client, clusterID, req)
 	if err != nil {
 		return diag.Errorf("error creating node pool: %v", err)
 	}
@@ -46,10 +81,10 @@ func resourceVultrKubernetesNodePoolsCreate(ctx context.Context, d *schema.Resou
 	d.SetId(nodePool.ID)
 	d.Set("cluster_id", clusterID)
 
-	//block until status is ready
-	if _, err = waitForNodePoolAvailable(ctx, d, "active", []string{"pending"}, "status", meta); err != nil {
+	// The pool reports active before its nodes are, so wait for the nodes too
+	if _, err = waitForNodePoolReady(ctx, client, clusterID, d.Id(), d.Timeout(schema.TimeoutCreate)); err != nil {
 		return diag.Errorf(
-			"error while waiting for node pool %v to be completed: %v", d.Id(), err)
+			"error while waiting for node pool %v to be ready: %v", d.Id(), err)
 	}
 
 	return resourceVultrKubernetesNodePoolsRead(ctx, d, meta)
@@ -60,7 +95,7 @@ func resourceVultrKubernetesNodePoolsRead(ctx context.Context, d *schema.Resourc
 
 	clusterID := d.Get("cluster_id").(string)
 
-	nodePool, err := client.Kubernetes.GetNodePool(ctx, clusterID, d.Id())
+	nodePool, err := getVKENodePool(ctx, client, clusterID, d.Id())
 	if err != nil {
 		if strings.Contains(err.Error(), "Unauthorized") {
 			return diag.Errorf("API authorization error: %v", err)
@@ -76,26 +111,22 @@ func resourceVultrKubernetesNodePoolsRead(ctx context.Context, d *schema.Resourc
 	d.Set("status", nodePool.Status)
 	d.Set("label", nodePool.Label)
 	d.Set("plan", nodePool.Plan)
-	d.Set("tag", nodePool.Tag)
+	d.Set("tag", meta.(*Client).stateTag(d.Get("tag").(string), nodePool.Tag))
 	d.Set("node_quantity", nodePool.NodeQuantity)
 	d.Set("date_created", nodePool.DateCreated)
 	d.Set("date_updated", nodePool.DateUpdated)
 	d.Set("auto_scaler", nodePool.AutoScaler)
 	d.Set("min_nodes", nodePool.MinNodes)
 	d.Set("max_nodes", nodePool.MaxNodes)
-
-	var instances []map[string]interface{}
-	for _, v := range nodePool.Nodes {
-		n := map[string]interface{}{
-			"id":           v.ID,
-			"status":       v.Status,
-			"date_created": v.DateCreated,
-			"label":        v.Label,
-		}
-		instances = append(instances, n)
+	d.Set("labels", nodePool.Labels)
+	if err := d.Set("taints", flattenNodePoolTaints(nodePool.Taints)); err != nil {
+		return diag.Errorf("error setting `taints`: %v", err)
 	}
 
-	d.Set("nodes", instances)
+	d.Set("ready", isNodePoolReady(&nodePool.NodePool))
+
+	pools := flattenNodePool(nodePool, nodeMainIPs(ctx, client, nodePool.Tag, nodePool.Nodes))
+	d.Set("nodes", pools[0]["nodes"])
 
 	return nil
 }
@@ -105,16 +136,22 @@ func resourceVultrKubernetesNodePoolsUpdate(ctx context.Context, d *schema.Resou
 
 	clusterID := d.Get("cluster_id").(string)
 
-	req := &govultr.NodePoolReqUpdate{
-		NodeQuantity: d.Get("node_quantity").(int),
-		Tag:          govultr.StringToStringPtr(d.Get("tag").(string)),
-		AutoScaler:   govultr.BoolToBoolPtr(d.Get("auto_scaler").(bool)),
-		MinNodes:     d.Get("min_nodes").(int),
-		MaxNodes:     d.Get("max_nodes").(int),
+	req := nodePoolUpdateReq(
+		d.Get("node_quantity").(int),
+		meta.(*Client).tagOrDefault(d.Get("tag").(string)),
+		d.Get("auto_scaler").(bool),
+		d.Get("min_nodes").(int),
+		d.Get("max_nodes").(int),
+		d.Get("labels").(map[string]interface{}),
+		d.Get("taints").(*schema.Set),
+	)
+
+	if _, err := updateVKENodePool(ctx, client, clusterID, d.Id(), req); err != nil {
+		return diag.Errorf("error deleting VKE node pool %v : %v", d.Id(), err)
 	}
 
-	if _, err := client.Kubernetes.UpdateNodePool(ctx, clusterID, d.Id(), req); err != nil {
-		return diag.Errorf("error deleting VKE node pool %v : %v", d.Id(), err)
+	if _, err := waitForNodePoolReady(ctx, client, clusterID, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.Errorf("error while waiting for node pool %v to be ready: %v", d.Id(), err)
 	}
 
 	return resourceVultrKubernetesNodePoolsRead(ctx, d, meta)
@@ -130,16 +167,35 @@ func resourceVultrKubernetesNodePoolsDelete(ctx context.Context, d *schema.Resou
 	return nil
 }
 
-func waitForNodePoolAvailable(ctx context.Context, d *schema.ResourceData, target string, pending []string, attribute string, meta interface{}) (interface{}, error) {
-	log.Printf(
-		"[INFO] Waiting for node pool (%s) to have %s of %s",
-		d.Id(), attribute, target)
+func resourceVultrKubernetesNodePoolsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*Client).govultrClient()
+
+	importID := d.Id()
+	colonIdx := strings.IndexByte(importID, ':')
+	if colonIdx == -1 {
+		return nil, fmt.Errorf(`invalid import format, expected "clusterID:nodePoolID"`)
+	}
+	clusterID, nodePoolID := importID[:colonIdx], importID[colonIdx+1:]
+
+	nodePool, err := client.Kubernetes.GetNodePool(ctx, clusterID, nodePoolID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting node pool %s for cluster %s: %w", nodePoolID, clusterID, err)
+	}
+
+	d.SetId(nodePool.ID)
+	d.Set("cluster_id", clusterID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// waitForNodePoolReady blocks until the node pool and every node within it report active
+func waitForNodePoolReady(ctx context.Context, client *govultr.Client, clusterID, nodePoolID string, timeout time.Duration) (interface{}, error) {
+	log.Printf("[INFO] Waiting for node pool (%s) nodes to be active", nodePoolID)
 
 	stateConf := &resource.StateChangeConf{
-		Pending:        pending,
-		Target:         []string{target},
-		Refresh:        newNodePoolStateRefresh(ctx, d, meta, attribute),
-		Timeout:        60 * time.Minute,
+		Pending:        []string{"pending"},
+		Target:         []string{"active"},
+		Refresh:        newNodePoolReadyRefresh(ctx, client, clusterID, nodePoolID),
+		Timeout:        timeout,
 		Delay:          10 * time.Second,
 		MinTimeout:     5 * time.Second,
 		NotFoundChecks: 60,
@@ -148,22 +204,20 @@ func waitForNodePoolAvailable(ctx context.Context, d *schema.ResourceData, targe
 	return stateConf.WaitForStateContext(ctx)
 }
 
-func newNodePoolStateRefresh(ctx context.Context, d *schema.ResourceData, meta interface{}, attr string) resource.StateRefreshFunc {
-	client := meta.(*Client).govultrClient()
+func newNodePoolReadyRefresh(ctx context.Context, client *govultr.Client, clusterID, nodePoolID string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-
-		log.Printf("[INFO] Creating node pool")
-
-		np, err := client.Kubernetes.GetNodePool(ctx, d.Get("cluster_id").(string), d.Id())
+		np, err := getVKENodePool(ctx, client, clusterID, nodePoolID)
 		if err != nil {
-			return nil, "", fmt.Errorf("error retrieving node pool %s ", d.Id())
+			return nil, "", fmt.Errorf("error retrieving node pool %s ", nodePoolID)
 		}
 
-		if attr == "status" {
-			log.Printf("[INFO] The node pool status is %v", np.Status)
-			return np, np.Status, nil
+		// Scaling down removes nodes after the pool reports active again, so
+		// also wait for the node list to settle at the new size.
+		if np.Status != "active" || !isNodePoolReady(&np.NodePool) {
+			log.Printf("[INFO] The node pool status is %v with %d of %d nodes", np.Status, len(np.Nodes), np.NodeQuantity)
+			return np, "pending", nil
 		}
 
-		return nil, "", nil
+		return np, "active", nil
 	}
 }
@@ -1,11 +1,18 @@
 package vultr
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vultr/govultr/v2"
 )
 
 func TestAccResourceVultrKubernetesNodePools(t *testing.T) {
@@ -28,6 +35,12 @@ func TestAccResourceVultrKubernetesNodePools(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "plan", "vc2-2c-4gb"),
 				),
 			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testNodePoolImportID("vultr_kubernetes.foo", name),
+			},
 		},
 	})
 }
@@ -106,6 +119,125 @@ func TestAccResourceVultrKubernetesNodePoolsUpdateAutoScaler(t *testing.T) {
 	})
 }
 
+func TestAccResourceVultrKubernetesNodePoolsTaints(t *testing.T) {
+	skipCI(t)
+	rLabel := acctest.RandomWithPrefix("tf-vke-rs")
+	rNP := acctest.RandomWithPrefix("tf-vke-np")
+
+	name := "vultr_kubernetes_node_pools.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVultrKubernetesBase(rLabel) + testAccVultrKubernetesNodePoolsTaints(rNP),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "labels.%", "1"),
+					resource.TestCheckResourceAttr(name, "labels.role", "gpu"),
+					resource.TestCheckResourceAttr(name, "taints.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateNodePoolAutoScaler(t *testing.T) {
+	tests := []struct {
+		name       string
+		autoScaler bool
+		min        int
+		max        int
+		quantity   int
+		err        string
+	}{
+		{"disabled ignores bounds", false, 0, 0, 3, ""},
+		{"valid bounds", true, 1, 3, 2, ""},
+		{"quantity outside bounds only warns", true, 2, 3, 5, ""},
+		{"min below one", true, 0, 3, 1, "min_nodes must be at least 1"},
+		{"max below min", true, 3, 2, 3, "max_nodes (2) cannot be less than min_nodes (3)"},
+	}
+
+	for _, tt := range tests {
+		err := validateNodePoolAutoScaler(tt.autoScaler, tt.min, tt.max, tt.quantity)
+		if tt.err == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tt.name, err)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.err, err)
+		}
+	}
+}
+
+func TestValidateNodePoolAutoScalerEnable(t *testing.T) {
+	if err := validateNodePoolAutoScalerEnable(true, true); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, bounds := range [][2]bool{{false, true}, {true, false}, {false, false}} {
+		err := validateNodePoolAutoScalerEnable(bounds[0], bounds[1])
+		if err == nil || !strings.Contains(err.Error(), "min_nodes and max_nodes must be set") {
+			t.Errorf("expected an error for bounds set %v, got %v", bounds, err)
+		}
+	}
+}
+
+func TestResourceVultrKubernetesNodePoolsAutoScalerBounds(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"node_pool":{"id":"pool","label":"workers","plan":"vc2-2c-4gb","status":"active","node_quantity":2,"auto_scaler":false,"min_nodes":0,"max_nodes":0}}`)
+	})
+
+	// Read takes the bounds from the API, which clears them with the auto scaler
+	r := resourceVultrKubernetesNodePools()
+	d := r.TestResourceData()
+	d.SetId("pool")
+	d.Set("cluster_id", "cluster")
+	d.Set("min_nodes", 2)
+	d.Set("max_nodes", 4)
+	if diags := resourceVultrKubernetesNodePoolsRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Get("min_nodes").(int) != 0 || d.Get("max_nodes").(int) != 0 {
+		t.Fatalf("expected the cleared bounds, got %d and %d", d.Get("min_nodes"), d.Get("max_nodes"))
+	}
+
+	config := func(autoScaler bool) *terraform.ResourceConfig {
+		raw := map[string]interface{}{
+			"cluster_id":    "cluster",
+			"label":         "workers",
+			"plan":          "vc2-2c-4gb",
+			"node_quantity": 2,
+			"auto_scaler":   autoScaler,
+		}
+		if autoScaler {
+			raw["min_nodes"] = 2
+			raw["max_nodes"] = 4
+		}
+		return terraform.NewResourceConfigRaw(raw)
+	}
+
+	// The cleared bounds don't differ from the defaults in config while the
+	// auto scaler stays disabled
+	diff, err := r.Diff(context.Background(), d.State(), config(false), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff != nil && (diff.Attributes["min_nodes"] != nil || diff.Attributes["max_nodes"] != nil) {
+		t.Fatalf("expected no bounds diff, got %#v", diff.Attributes)
+	}
+
+	diff, err = r.Diff(context.Background(), d.State(), config(true), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff == nil || diff.Attributes["min_nodes"] == nil || diff.Attributes["min_nodes"].New != "2" {
+		t.Fatalf("expected a min_nodes diff when enabling the auto scaler, got %#v", diff)
+	}
+}
+
 func testAccVultrKubernetesNodePoolsBase(label string) string {
 	return fmt.Sprintf(`
 		resource "vultr_kubernetes_node_pools" "foo" {
@@ -144,3 +276,115 @@ func testAccVultrKubernetesNodePoolsUpdateAutoScaler(label string) string {
 				max_nodes = 5
 		}`, label)
 }
+
+func testAccVultrKubernetesNodePoolsTaints(label string) string {
+	return fmt.Sprintf(`
+		resource "vultr_kubernetes_node_pools" "foo" {
+			cluster_id = vultr_kubernetes.foo.id
+			node_quantity = 1
+			plan = "vc2-2c-4gb"
+			label = "%s"
+
+			labels = {
+				role = "gpu"
+			}
+
+			taints {
+				key    = "dedicated"
+				value  = "gpu"
+				effect = "NoSchedule"
+			}
+		}`, label)
+}
+
+func testNodePoolImportID(c, np string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[c]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", c)
+		}
+
+		rs2, ok := s.RootModule().Resources[np]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", np)
+		}
+
+		return fmt.Sprintf("%s:%s", rs.Primary.Attributes["id"], rs2.Primary.Attributes["id"]), nil
+	}
+}
+
+func TestIsNodePoolReady(t *testing.T) {
+	nodes := func(statuses ...string) []govultr.Node {
+		var n []govultr.Node
+		for i, s := range statuses {
+			n = append(n, govultr.Node{ID: fmt.Sprintf("node-%d", i), Status: s})
+		}
+		return n
+	}
+
+	cases := map[string]struct {
+		pool  govultr.NodePool
+		ready bool
+	}{
+		"all active":       {govultr.NodePool{NodeQuantity: 2, Nodes: nodes("active", "active")}, true},
+		"node pending":     {govultr.NodePool{NodeQuantity: 2, Nodes: nodes("active", "pending")}, false},
+		"missing node":     {govultr.NodePool{NodeQuantity: 3, Nodes: nodes("active", "active")}, false},
+		"scaled within":    {govultr.NodePool{NodeQuantity: 1, AutoScaler: true, MinNodes: 1, MaxNodes: 3, Nodes: nodes("active", "active")}, true},
+		"scaled below min": {govultr.NodePool{NodeQuantity: 2, AutoScaler: true, MinNodes: 2, MaxNodes: 3, Nodes: nodes("active")}, false},
+	}
+
+	for name, tc := range cases {
+		pool := tc.pool
+		if got := isNodePoolReady(&pool); got != tc.ready {
+			t.Errorf("%s: isNodePoolReady = %t, expected %t", name, got, tc.ready)
+		}
+	}
+}
+
+func TestNewNodePoolReadyRefreshScaleDown(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/kubernetes/clusters/cluster/node-pools/pool" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		n := atomic.AddInt32(&requests, 1)
+		if n == 1 {
+			// The pool is active while the removed node is still terminating
+			fmt.Fprint(w, `{"node_pool":{"id":"pool","status":"active","node_quantity":1,"nodes":[{"id":"node-1","status":"active"},{"id":"node-2","status":"active"}]}}`)
+			return
+		}
+		fmt.Fprint(w, `{"node_pool":{"id":"pool","status":"active","node_quantity":1,"nodes":[{"id":"node-1","status":"active"}]}}`)
+	}).govultrClient()
+
+	refresh := newNodePoolReadyRefresh(context.Background(), client, "cluster", "pool")
+	for _, expected := range []string{"pending", "active"} {
+		_, state, err := refresh()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if state != expected {
+			t.Errorf("expected state %q, got %q", expected, state)
+		}
+	}
+}
+
+func TestResourceVultrKubernetesNodePoolsImport(t *testing.T) {
+	r := resourceVultrKubernetesNodePools()
+
+	d := r.TestResourceData()
+	d.SetId("cluster:pool")
+	if _, err := resourceVultrKubernetesNodePoolsImport(context.Background(), d, newFakeClient(&fakeKubernetesService{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Id() != "pool" || d.Get("cluster_id") != "cluster" {
+		t.Errorf("expected pool in cluster, got %s in %v", d.Id(), d.Get("cluster_id"))
+	}
+
+	d = r.TestResourceData()
+	d.SetId("cluster:pool")
+	kubernetes := &fakeKubernetesService{err: errors.New("unauthorized")}
+	_, err := resourceVultrKubernetesNodePoolsImport(context.Background(), d, newFakeClient(kubernetes))
+	if err == nil || !strings.Contains(err.Error(), "pool") || !errors.Is(err, kubernetes.err) {
+		t.Errorf("expected the API error wrapped with the node pool ID, got %v", err)
+	}
+}
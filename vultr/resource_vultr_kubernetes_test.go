@@ -1,11 +1,27 @@
 package vultr
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vultr/govultr/v2"
 )
 
 func TestAccResourceVultrKubernetes(t *testing.T) {
@@ -23,6 +39,7 @@ func TestAccResourceVultrKubernetes(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "label", rLabel),
 					resource.TestCheckResourceAttrSet(name, "region"),
 					resource.TestCheckResourceAttrSet(name, "status"),
+					resource.TestCheckResourceAttrSet(name, "firewall_group_id"),
 					resource.TestCheckResourceAttr(name, "node_pools.#", "1"),
 					resource.TestCheckResourceAttr(name, "node_pools.0.node_quantity", "1"),
 					resource.TestCheckResourceAttr(name, "node_pools.0.plan", "vc2-2c-4gb"),
@@ -67,12 +84,47 @@ func TestAccResourceVultrKubernetesUpdate(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "node_pools.0.auto_scaler", "true"),
 					resource.TestCheckResourceAttr(name, "node_pools.0.min_nodes", "2"),
 					resource.TestCheckResourceAttr(name, "node_pools.0.max_nodes", "3"),
+					resource.TestCheckResourceAttr(name, "node_pools.0.tag", "tf-test-tag"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccResourceVultrKubernetesHA(t *testing.T) {
+	skipCI(t)
+	rLabel := acctest.RandomWithPrefix("tf-vke-rs-")
+
+	name := "vultr_kubernetes.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVultrKubernetesHA(rLabel),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "ha_controlplanes", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceVultrKubernetesNoNodePool(t *testing.T) {
+	rLabel := acctest.RandomWithPrefix("tf-vke-rs-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccVultrKubernetesNoNodePool(rLabel),
+				ExpectError: regexp.MustCompile(`node_pools must contain a node pool when creating a kubernetes cluster`),
+			},
+		},
+	})
+}
+
 func testAccVultrKubernetesBase(label string) string {
 	return fmt.Sprintf(`
 		resource "vultr_kubernetes" "foo" {
@@ -88,6 +140,31 @@ func testAccVultrKubernetesBase(label string) string {
 		}`, label)
 }
 
+func testAccVultrKubernetesNoNodePool(label string) string {
+	return fmt.Sprintf(`
+		resource "vultr_kubernetes" "foo" {
+			region   = "ewr"
+			label       = "%s"
+			version = "v1.24.3+2"
+		}`, label)
+}
+
+func testAccVultrKubernetesHA(label string) string {
+	return fmt.Sprintf(`
+		resource "vultr_kubernetes" "foo" {
+			region   = "ewr"
+			label       = "%s"
+			version = "v1.24.3+2"
+			ha_controlplanes = true
+
+			node_pools {
+				node_quantity = 1
+				plan = "vc2-2c-4gb"
+				label = "tf-test-label"
+			}
+		}`, label)
+}
+
 func testAccVultrKubernetesUpdate(label string) string {
 	return fmt.Sprintf(`
 		resource "vultr_kubernetes" "foo" {
@@ -99,9 +176,1185 @@ func testAccVultrKubernetesUpdate(label string) string {
 				node_quantity = 2
 				plan = "vc2-2c-4gb"
     			label = "tf-test-label"
+				tag = "tf-test-tag"
 				auto_scaler = true
 				min_nodes = 2
 				max_nodes = 3
 			}
 		}`, label)
 }
+
+func TestIsNewerVKEVersion(t *testing.T) {
+	cases := []struct {
+		current string
+		target  string
+		newer   bool
+	}{
+		{"v1.24.3+2", "v1.24.4+1", true},
+		{"v1.24.3+2", "v1.25.0+1", true},
+		{"v1.24.3+1", "v1.24.3+2", true},
+		{"v1.24.3+2", "v1.24.3+2", false},
+		{"v1.24.3+2", "v1.23.9+3", false},
+		{"v1.24.10+1", "v1.24.9+1", false},
+	}
+
+	for _, c := range cases {
+		newer, err := isNewerVKEVersion(c.current, c.target)
+		if err != nil {
+			t.Fatalf("unexpected error comparing %s to %s: %v", c.current, c.target, err)
+		}
+		if newer != c.newer {
+			t.Errorf("isNewerVKEVersion(%s, %s) = %v, expected %v", c.current, c.target, newer, c.newer)
+		}
+	}
+
+	if _, err := isNewerVKEVersion("v1.24.3+2", "latest"); err == nil {
+		t.Error("expected error for invalid version")
+	}
+}
+
+func TestWriteKubeConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	path := filepath.Join(home, ".kube", "config")
+	for _, config := range []string{"apiVersion: v1\n", "apiVersion: v1\nkind: Config\n"} {
+		if err := writeKubeConfig("~/.kube/config", base64.StdEncoding.EncodeToString([]byte(config))); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		written, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(written) != config {
+			t.Fatalf("expected the rotated kubeconfig %q to be written, got %q", config, written)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Fatalf("expected the kubeconfig to have 0600 permissions, got %o", perm)
+	}
+}
+
+func TestParseKubeConfig(t *testing.T) {
+	encode := func(v string) string {
+		return base64.StdEncoding.EncodeToString([]byte(v))
+	}
+
+	config := fmt.Sprintf(`apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: %s
+    server: https://example.vultr-k8s.com:6443
+  name: vke
+contexts:
+- context:
+    cluster: vke
+    user: admin
+  name: vke
+current-context: vke
+kind: Config
+users:
+- name: admin
+  user:
+    client-certificate-data: %s
+    client-key-data: %s
+`, encode("ca-cert"), encode("client-cert"), encode("client-key"))
+
+	creds, err := parseKubeConfig(encode(config))
+	if err != nil {
+		t.Fatalf("unexpected error parsing kubeconfig: %v", err)
+	}
+
+	if creds.Host != "https://example.vultr-k8s.com:6443" {
+		t.Errorf("unexpected host %q", creds.Host)
+	}
+	if creds.ClusterCACertificate != "ca-cert" {
+		t.Errorf("unexpected cluster ca certificate %q", creds.ClusterCACertificate)
+	}
+	if creds.ClientCertificate != "client-cert" {
+		t.Errorf("unexpected client certificate %q", creds.ClientCertificate)
+	}
+	if creds.ClientKey != "client-key" {
+		t.Errorf("unexpected client key %q", creds.ClientKey)
+	}
+
+	if _, err := parseKubeConfig(encode("kind: Config")); err == nil {
+		t.Error("expected error for kubeconfig without clusters")
+	}
+}
+
+func TestResourceVultrKubernetesUpdateNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":"Invalid resource ID","status":404}`)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceVultrKubernetes().Schema, map[string]interface{}{
+		"label":   "tf-vke",
+		"region":  "ewr",
+		"version": "v1.24.3+2",
+	})
+	d.SetId("0b2a1e8e-6d0b-4b87-9a9e-bfa2b0b5f1c2")
+
+	if diags := resourceVultrKubernetesUpdate(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Id() != "" {
+		t.Fatalf("expected cluster to be removed from state, got ID %q", d.Id())
+	}
+}
+
+func TestResourceVultrKubernetesImportLabel(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			fmt.Fprint(w, `{"vke_clusters":[{"id":"cluster-1","label":"web"},{"id":"cluster-2","label":"db"}],"meta":{"total":4,"links":{"next":"page-2","prev":""}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"vke_clusters":[{"id":"cluster-3","label":"api"},{"id":"cluster-4","label":"web"}],"meta":{"total":4,"links":{"next":"","prev":""}}}`)
+	})
+
+	tests := []struct {
+		id       string
+		expected string
+		err      string
+	}{
+		{"cluster-9", "cluster-9", ""},
+		{"label:api", "cluster-3", ""},
+		{"label:db", "cluster-2", ""},
+		{"label:web", "", "2 VKE clusters found"},
+		{"label:cache", "", "no VKE cluster found"},
+	}
+
+	for _, tt := range tests {
+		d := resourceVultrKubernetes().TestResourceData()
+		d.SetId(tt.id)
+
+		_, err := resourceVultrKubernetesImport(context.Background(), d, client)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: expected error containing %q, got %v", tt.id, tt.err, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.id, err)
+			continue
+		}
+		if d.Id() != tt.expected {
+			t.Errorf("%s: expected ID %q, got %q", tt.id, tt.expected, d.Id())
+		}
+	}
+}
+
+func TestResourceVultrKubernetesUpdatePartialFailure(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/v2/kubernetes/clusters/cluster":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPatch && r.URL.Path == "/v2/kubernetes/clusters/cluster/node-pools/pool-1":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"node quantity is above the plan limit","status":400}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/kubernetes/clusters/cluster":
+			fmt.Fprint(w, `{"vke_cluster":{"id":"cluster","label":"new","version":"v1.25.4+1","status":"pending","node_pools":[{"id":"pool-1","label":"np","plan":"vc2-1c-2gb","node_quantity":1,"min_nodes":1,"max_nodes":1}]}}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"unexpected request","status":400}`)
+		}
+	})
+
+	state := &terraform.InstanceState{
+		ID: "cluster",
+		Attributes: map[string]string{
+			"id":                         "cluster",
+			"label":                      "old",
+			"region":                     "ewr",
+			"version":                    "v1.25.4+1",
+			"ha_controlplanes":           "false",
+			"node_pools.#":               "1",
+			"node_pools.0.id":            "pool-1",
+			"node_pools.0.label":         "np",
+			"node_pools.0.plan":          "vc2-1c-2gb",
+			"node_pools.0.node_quantity": "1",
+			"node_pools.0.min_nodes":     "1",
+			"node_pools.0.max_nodes":     "1",
+			"node_pools.0.auto_scaler":   "false",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"label":   "new",
+		"region":  "ewr",
+		"version": "v1.25.4+1",
+		"node_pools": []interface{}{
+			map[string]interface{}{
+				"label":         "np",
+				"plan":          "vc2-1c-2gb",
+				"node_quantity": 5,
+			},
+		},
+	})
+
+	r := resourceVultrKubernetes()
+	diff, err := r.Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	newState, diags := r.Apply(context.Background(), state, diff, client)
+	if !diags.HasError() {
+		t.Fatal("expected the node pool update to fail")
+	}
+
+	if newState.Attributes["label"] != "new" {
+		t.Errorf("expected the applied label to be kept, got %q", newState.Attributes["label"])
+	}
+
+	if newState.Attributes["node_pools.0.node_quantity"] != "1" {
+		t.Errorf("expected node_quantity from the API, got %q", newState.Attributes["node_pools.0.node_quantity"])
+	}
+}
+
+func TestResourceVultrKubernetesUpdateRemoveTag(t *testing.T) {
+	var mu sync.Mutex
+	var tags []interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPatch && r.URL.Path == "/v2/kubernetes/clusters/cluster/node-pools/pool-1":
+			var req map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("error decoding request: %v", err)
+			}
+			mu.Lock()
+			tags = append(tags, req["tag"])
+			mu.Unlock()
+			// Fail the update so the test doesn't wait for the pool to be ready
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"stop here","status":400}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"unexpected request","status":400}`)
+		}
+	})
+	client.defaultTag = "team-a"
+
+	state := &terraform.InstanceState{
+		ID: "cluster",
+		Attributes: map[string]string{
+			"id":                         "cluster",
+			"label":                      "cluster",
+			"region":                     "ewr",
+			"version":                    "v1.25.4+1",
+			"tag":                        "web",
+			"ha_controlplanes":           "false",
+			"include_kube_config":        "false",
+			"node_pools.#":               "1",
+			"node_pools.0.id":            "pool-1",
+			"node_pools.0.label":         "np",
+			"node_pools.0.plan":          "vc2-1c-2gb",
+			"node_pools.0.node_quantity": "1",
+			"node_pools.0.tag":           "web",
+			"node_pools.0.min_nodes":     "1",
+			"node_pools.0.max_nodes":     "1",
+			"node_pools.0.auto_scaler":   "false",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"label":               "cluster",
+		"region":              "ewr",
+		"version":             "v1.25.4+1",
+		"include_kube_config": false,
+		"node_pools": []interface{}{
+			map[string]interface{}{
+				"label":         "np",
+				"plan":          "vc2-1c-2gb",
+				"node_quantity": 1,
+			},
+		},
+	})
+
+	r := resourceVultrKubernetes()
+	diff, err := r.Diff(context.Background(), state, config, client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, diags := r.Apply(context.Background(), state, diff, client); !diags.HasError() {
+		t.Fatal("expected the failed pool update to be returned")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(tags) != 1 || tags[0] != "team-a" {
+		t.Errorf("expected the default tag to be applied to the pool, got %v", tags)
+	}
+}
+
+func TestResourceVultrKubernetesCustomizeDiffRegion(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"regions":[{"id":"ewr","options":["ddos_protection","kubernetes"]},{"id":"sao","options":["ddos_protection"]}],"meta":{"total":2,"links":{"next":"","prev":""}}}`)
+	})
+
+	config := func(region string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"label":   "cluster",
+			"region":  region,
+			"version": "v1.25.4+1",
+			"node_pools": []interface{}{
+				map[string]interface{}{
+					"label":         "np",
+					"plan":          "vc2-1c-2gb",
+					"node_quantity": 1,
+				},
+			},
+		})
+	}
+
+	r := resourceVultrKubernetes()
+	if _, err := r.Diff(context.Background(), nil, config("ewr"), client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err := r.Diff(context.Background(), nil, config("sao"), client)
+	if err == nil || !strings.Contains(err.Error(), "VKE is not available in region sao") {
+		t.Fatalf("expected a region error, got %v", err)
+	}
+}
+
+func TestResourceVultrKubernetesRegionCasing(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"regions":[{"id":"ewr","options":["kubernetes"]}],"meta":{"total":1,"links":{"next":"","prev":""}}}`)
+	})
+
+	config := func(region string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"label":   "cluster",
+			"region":  region,
+			"version": "v1.25.4+1",
+			"node_pools": []interface{}{
+				map[string]interface{}{
+					"label":         "np",
+					"plan":          "vc2-1c-2gb",
+					"node_quantity": 1,
+				},
+			},
+		})
+	}
+
+	r := resourceVultrKubernetes()
+	diff, err := r.Diff(context.Background(), nil, config("EWR"), client)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if region := diff.Attributes["region"]; region == nil || region.New != "ewr" {
+		t.Fatalf("expected the region to be lowercased, got %#v", region)
+	}
+
+	_, err = r.Diff(context.Background(), nil, config("xyz"), client)
+	if err == nil || !strings.Contains(err.Error(), "region xyz not found, valid regions are: ewr") {
+		t.Fatalf("expected an error listing the valid regions, got %v", err)
+	}
+
+	if diags := r.Validate(config("ew r")); !diags.HasError() {
+		t.Fatal("expected a malformed region to be rejected")
+	}
+}
+
+func TestResourceVultrKubernetesCustomizeDiffSubnets(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"regions":[{"id":"ewr","options":["kubernetes"]}],"meta":{"total":1,"links":{"next":"","prev":""}}}`)
+	})
+
+	config := func(clusterSubnet string) *terraform.ResourceConfig {
+		raw := map[string]interface{}{
+			"label":   "cluster",
+			"region":  "ewr",
+			"version": "v1.25.4+1",
+			"node_pools": []interface{}{
+				map[string]interface{}{
+					"label":         "np",
+					"plan":          "vc2-1c-2gb",
+					"node_quantity": 1,
+				},
+			},
+		}
+		if clusterSubnet != "" {
+			raw["cluster_subnet"] = clusterSubnet
+		}
+		return terraform.NewResourceConfigRaw(raw)
+	}
+
+	state := &terraform.InstanceState{
+		ID: "cluster-id",
+		Attributes: map[string]string{
+			"label":            "cluster",
+			"region":           "ewr",
+			"version":          "v1.25.4+1",
+			"ha_controlplanes": "false",
+			"cluster_subnet":   "10.244.0.0/16",
+			"service_subnet":   "10.96.0.0/12",
+		},
+	}
+
+	r := resourceVultrKubernetes()
+	diff, err := r.Diff(context.Background(), nil, config(""), client)
+	if err != nil {
+		t.Fatalf("unexpected error creating without subnets: %v", err)
+	}
+	if attr := diff.Attributes["cluster_subnet"]; attr == nil || !attr.NewComputed {
+		t.Errorf("expected cluster_subnet to be assigned by the API, got %+v", attr)
+	}
+
+	diff, err = r.Diff(context.Background(), nil, config("10.10.0.0/16"), client)
+	if err != nil {
+		t.Fatalf("unexpected error creating with a subnet: %v", err)
+	}
+	if attr := diff.Attributes["cluster_subnet"]; attr == nil || attr.New != "10.10.0.0/16" {
+		t.Errorf("expected cluster_subnet 10.10.0.0/16, got %+v", attr)
+	}
+
+	diff, err = r.Diff(context.Background(), state, config("10.244.0.0/16"), client)
+	if err != nil {
+		t.Fatalf("unexpected error pinning the current subnet: %v", err)
+	}
+	if diff != nil && diff.RequiresNew() {
+		t.Error("expected pinning the current subnet not to replace the cluster")
+	}
+
+	diff, err = r.Diff(context.Background(), state, config("10.10.0.0/16"), client)
+	if err != nil {
+		t.Fatalf("unexpected error changing the subnet: %v", err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Error("expected changing the subnet to replace the cluster")
+	}
+
+	if diags := r.Validate(config("10.10.0.0")); !diags.HasError() {
+		t.Fatal("expected a subnet without a prefix length to be rejected")
+	}
+}
+
+func TestResourceVultrKubernetesCustomizeDiffNodePoolBootstrap(t *testing.T) {
+	for _, key := range []string{"script_id", "user_data"} {
+		pool := map[string]interface{}{
+			"label":         "np",
+			"plan":          "vc2-1c-2gb",
+			"node_quantity": 1,
+			key:             "bootstrap",
+		}
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"label":      "cluster",
+			"region":     "ewr",
+			"version":    "v1.25.4+1",
+			"node_pools": []interface{}{pool},
+		})
+
+		state := &terraform.InstanceState{
+			ID: "cluster",
+			Attributes: map[string]string{
+				"id":      "cluster",
+				"label":   "cluster",
+				"region":  "ewr",
+				"version": "v1.25.4+1",
+			},
+		}
+
+		_, err := resourceVultrKubernetes().Diff(context.Background(), state, config, &Client{})
+		if err == nil || !strings.Contains(err.Error(), "script_id and user_data are not supported") {
+			t.Errorf("expected node pool %s to be rejected, got %v", key, err)
+		}
+	}
+}
+
+func TestNewVKEStateRefreshEndpoint(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		switch n {
+		case 1:
+			fmt.Fprint(w, `{"vke_cluster":{"id":"cluster","status":"active"}}`)
+		default:
+			fmt.Fprint(w, `{"vke_cluster":{"id":"cluster","status":"active","endpoint":"cluster.vultr-k8s.com","ip":"192.0.2.10"}}`)
+		}
+	})
+
+	d := resourceVultrKubernetes().TestResourceData()
+	d.SetId("cluster")
+	refresh := newVKEStateRefresh(context.Background(), d, client, "endpoint")
+
+	for _, expected := range []string{"pending", "active"} {
+		_, state, err := refresh()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if state != expected {
+			t.Errorf("expected state %q, got %q", expected, state)
+		}
+	}
+}
+
+func TestNewVKEStateRefreshFailed(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"vke_cluster":{"id":"cluster","status":"error","node_pools":[{"id":"np-1","status":"error","nodes":[{"id":"node-1","status":"active"},{"id":"node-2","status":"error"}]}]}}`)
+	})
+
+	d := resourceVultrKubernetes().TestResourceData()
+	d.SetId("cluster")
+
+	for _, attr := range []string{"status", "endpoint"} {
+		_, state, err := newVKEStateRefresh(context.Background(), d, client, attr)()
+		if err == nil {
+			t.Fatalf("expected an error waiting on %s of a failed cluster", attr)
+		}
+		if state != "error" {
+			t.Errorf("expected state error, got %q", state)
+		}
+		if !strings.Contains(err.Error(), "node pool np-1 is error, node node-2 is error") {
+			t.Errorf("expected the failed pool and node in %q", err)
+		}
+	}
+}
+
+func TestWaitForVKEDeleted(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		if n < 3 {
+			fmt.Fprint(w, `{"vke_cluster":{"id":"cluster","status":"active"}}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":"Invalid resource ID","status":404}`)
+	}).govultrClient()
+
+	if err := waitForVKEDeleted(context.Background(), client, "cluster", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Fatalf("expected to poll until the cluster was gone, got %d requests", n)
+	}
+}
+
+func TestFindVKEDefaultNodePool(t *testing.T) {
+	pool := func(id, tag string) vkeNodePool {
+		return vkeNodePool{NodePool: govultr.NodePool{ID: id, Tag: tag}}
+	}
+	pools := []vkeNodePool{pool("pool-1", "workers"), pool("pool-2", tfVKEDefault), pool("pool-3", "custom")}
+
+	tests := []struct {
+		name     string
+		pools    []vkeNodePool
+		id       string
+		expected string
+	}{
+		{"matches id", pools, "pool-3", "pool-3"},
+		{"missing id", pools, "pool-4", ""},
+		{"legacy tag", pools, "", "pool-2"},
+		{"single pool", []vkeNodePool{pool("pool-1", "custom")}, "", "pool-1"},
+		{"ambiguous", []vkeNodePool{pool("pool-1", ""), pool("pool-3", "")}, "", ""},
+	}
+
+	for _, tt := range tests {
+		np := findVKEDefaultNodePool(tt.pools, tt.id)
+		got := ""
+		if np != nil {
+			got = np.ID
+		}
+		if got != tt.expected {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.expected, got)
+		}
+	}
+}
+
+func TestVKENodePoolTag(t *testing.T) {
+	meta := &Client{defaultTag: "terraform"}
+
+	tests := []struct {
+		poolTag, clusterTag, want string
+	}{
+		{"pool", "cluster", "pool"},
+		{"", "cluster", "cluster"},
+		{"", "", "terraform"},
+	}
+
+	for _, tt := range tests {
+		if got := vkeNodePoolTag(meta, tt.poolTag, tt.clusterTag); got != tt.want {
+			t.Errorf("vkeNodePoolTag(%q, %q) = %q, want %q", tt.poolTag, tt.clusterTag, got, tt.want)
+		}
+	}
+}
+
+// testNodePoolTaints returns taints as the node pool schema stores them.
+func testNodePoolTaints(taints ...interface{}) *schema.Set {
+	return schema.NewSet(schema.HashResource(nodePoolSchema(false)["taints"].Elem.(*schema.Resource)), taints)
+}
+
+func TestGenerateNodePool(t *testing.T) {
+	pools := []interface{}{
+		map[string]interface{}{
+			"node_quantity": 3,
+			"label":         "workers",
+			"plan":          "vc2-2c-4gb",
+			"tag":           "web",
+			"auto_scaler":   true,
+			"min_nodes":     1,
+			"max_nodes":     5,
+			"labels":        map[string]interface{}{"role": "web"},
+			"taints":        testNodePoolTaints(map[string]interface{}{"key": "dedicated", "value": "web", "effect": "NoSchedule"}),
+		},
+	}
+
+	expected := []vkeNodePoolReq{{
+		NodePoolReq: govultr.NodePoolReq{
+			NodeQuantity: 3,
+			Label:        "workers",
+			Plan:         "vc2-2c-4gb",
+			Tag:          "web",
+			AutoScaler:   govultr.BoolToBoolPtr(true),
+			MinNodes:     1,
+			MaxNodes:     5,
+		},
+		Labels: map[string]string{"role": "web"},
+		Taints: []vkeNodePoolTaint{{Key: "dedicated", Value: "web", Effect: "NoSchedule"}},
+	}}
+
+	if got := generateNodePool(pools); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+
+	if got := generateNodePool([]interface{}{}); got != nil {
+		t.Fatalf("expected no node pools, got %+v", got)
+	}
+}
+
+func TestFlattenNodePool(t *testing.T) {
+	np := &vkeNodePool{
+		NodePool: govultr.NodePool{
+			ID:           "pool-1",
+			Label:        "workers",
+			Plan:         "vc2-2c-4gb",
+			Status:       "active",
+			NodeQuantity: 2,
+			Tag:          "web",
+			MinNodes:     1,
+			MaxNodes:     2,
+			Nodes: []govultr.Node{
+				{ID: "node-1", Label: "workers-1", Status: "active"},
+				{ID: "node-2", Label: "workers-2", Status: "pending"},
+			},
+		},
+		Labels: map[string]string{"role": "web"},
+		Taints: []vkeNodePoolTaint{{Key: "dedicated", Value: "web", Effect: "NoSchedule"}},
+	}
+
+	pools := flattenNodePool(np, map[string]string{"node-1": "192.0.2.10"})
+	if len(pools) != 1 {
+		t.Fatalf("expected 1 node pool, got %d", len(pools))
+	}
+
+	pool := pools[0]
+	if pool["id"] != "pool-1" || pool["label"] != "workers" || pool["node_quantity"] != 2 || pool["tag"] != "web" {
+		t.Errorf("unexpected node pool %+v", pool)
+	}
+	if !reflect.DeepEqual(pool["labels"], np.Labels) {
+		t.Errorf("unexpected labels %v", pool["labels"])
+	}
+	if taints := pool["taints"].([]map[string]interface{}); len(taints) != 1 || taints[0]["key"] != "dedicated" || taints[0]["effect"] != "NoSchedule" {
+		t.Errorf("unexpected taints %v", taints)
+	}
+
+	nodes := pool["nodes"].([]map[string]interface{})
+	if len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %d", len(nodes))
+	}
+	if nodes[0]["main_ip"] != "192.0.2.10" || nodes[1]["main_ip"] != "" {
+		t.Errorf("unexpected node IPs %v and %v", nodes[0]["main_ip"], nodes[1]["main_ip"])
+	}
+	if nodes[0]["instance_id"] != "node-1" || nodes[1]["instance_id"] != "node-2" {
+		t.Errorf("unexpected node instance IDs %v and %v", nodes[0]["instance_id"], nodes[1]["instance_id"])
+	}
+}
+
+func TestApplyVKENodePoolChange(t *testing.T) {
+	oldPool := []interface{}{
+		map[string]interface{}{
+			"id":            "pool-1",
+			"node_quantity": 1,
+			"label":         "workers",
+			"plan":          "vc2-2c-4gb",
+			"tag":           "",
+			"auto_scaler":   false,
+			"min_nodes":     1,
+			"max_nodes":     1,
+			"labels":        map[string]interface{}{},
+			"taints":        testNodePoolTaints(),
+		},
+	}
+	newPool := []interface{}{
+		map[string]interface{}{
+			"id":            "pool-1",
+			"node_quantity": 3,
+			"label":         "workers",
+			"plan":          "vc2-2c-4gb",
+			"tag":           "web",
+			"auto_scaler":   true,
+			"min_nodes":     1,
+			"max_nodes":     3,
+			"labels":        map[string]interface{}{"role": "web"},
+			"taints":        testNodePoolTaints(map[string]interface{}{"key": "dedicated", "value": "web", "effect": "NoSchedule"}),
+		},
+	}
+
+	t.Run("update", func(t *testing.T) {
+		api := &fakeNodePoolAPI{}
+		id, err := applyVKENodePoolChange(context.Background(), newFakeNodePoolClient(t, api), "cluster", oldPool, newPool)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if id != "pool-1" {
+			t.Errorf("expected to wait for pool-1, got %q", id)
+		}
+		req, ok := api.updated["pool-1"]
+		if !ok || req["node_quantity"] != 3.0 || req["auto_scaler"] != true || req["max_nodes"] != 3.0 || req["tag"] != "web" {
+			t.Errorf("unexpected update request %+v", req)
+		}
+		if !reflect.DeepEqual(req["labels"], map[string]interface{}{"role": "web"}) {
+			t.Errorf("unexpected labels %v", req["labels"])
+		}
+		if !reflect.DeepEqual(req["taints"], []interface{}{map[string]interface{}{"key": "dedicated", "value": "web", "effect": "NoSchedule"}}) {
+			t.Errorf("unexpected taints %v", req["taints"])
+		}
+		if len(api.created) != 0 || len(api.deleted) != 0 {
+			t.Errorf("unexpected calls: created %v, deleted %v", api.created, api.deleted)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		fake := &fakeKubernetesService{}
+		id, err := applyVKENodePoolChange(context.Background(), newFakeClient(fake), "cluster", oldPool, []interface{}{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if id != "" {
+			t.Errorf("expected nothing to wait for, got %q", id)
+		}
+		if !reflect.DeepEqual(fake.deleted, []string{"pool-1"}) {
+			t.Errorf("expected pool-1 to be deleted, got %v", fake.deleted)
+		}
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		fake := &fakeKubernetesService{err: fmt.Errorf(`{"error":"Invalid resource ID","status":404}`)}
+		_, err := applyVKENodePoolChange(context.Background(), newFakeClient(fake), "cluster", oldPool, []interface{}{})
+		if err == nil || !isVKENotFound(err) {
+			t.Fatalf("expected a not found error, got %v", err)
+		}
+	})
+
+	t.Run("create", func(t *testing.T) {
+		api := &fakeNodePoolAPI{}
+		id, err := applyVKENodePoolChange(context.Background(), newFakeNodePoolClient(t, api), "cluster", []interface{}{}, newPool)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if id != "created-pool" {
+			t.Errorf("expected to wait for created-pool, got %q", id)
+		}
+		if len(api.created) != 1 || api.created[0]["node_quantity"] != 3.0 || api.created[0]["tag"] != "web" {
+			t.Errorf("unexpected create requests %+v", api.created)
+		}
+		if !reflect.DeepEqual(api.created[0]["labels"], map[string]interface{}{"role": "web"}) {
+			t.Errorf("unexpected labels %v", api.created[0]["labels"])
+		}
+	})
+
+	t.Run("default tag", func(t *testing.T) {
+		api := &fakeNodePoolAPI{}
+		client := newFakeNodePoolClient(t, api)
+		client.defaultTag = "team-a"
+		if _, err := applyVKENodePoolChange(context.Background(), client, "cluster", newPool, oldPool); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if tag := api.updated["pool-1"]["tag"]; tag != "team-a" {
+			t.Errorf("expected the default tag, got %v", tag)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		api := &fakeNodePoolAPI{err: `{"error":"Invalid resource ID","status":404}`}
+		_, err := applyVKENodePoolChange(context.Background(), newFakeNodePoolClient(t, api), "cluster", oldPool, newPool)
+		if err == nil || !isVKENotFound(err) {
+			t.Fatalf("expected a not found error, got %v", err)
+		}
+	})
+}
+
+func TestIsVKENodePoolReplace(t *testing.T) {
+	pool := func(plan string) []interface{} {
+		return []interface{}{map[string]interface{}{"id": "pool-1", "plan": plan, "node_quantity": 1}}
+	}
+
+	tests := []struct {
+		name     string
+		old, new []interface{}
+		expected bool
+	}{
+		{"plan change", pool("vc2-1c-2gb"), pool("vc2-2c-4gb"), true},
+		{"same plan", pool("vc2-1c-2gb"), pool("vc2-1c-2gb"), false},
+		{"new pool", []interface{}{}, pool("vc2-1c-2gb"), false},
+		{"removed pool", pool("vc2-1c-2gb"), []interface{}{}, false},
+	}
+
+	for _, tt := range tests {
+		if got := isVKENodePoolReplace(tt.old, tt.new); got != tt.expected {
+			t.Errorf("%s: expected %t, got %t", tt.name, tt.expected, got)
+		}
+	}
+
+	if !resourceVultrKubernetesNodePools().Schema["plan"].ForceNew {
+		t.Error("expected plan to force a new vultr_kubernetes_node_pools resource")
+	}
+}
+
+func TestApplyVKENodePoolChangeAutoScalerCycle(t *testing.T) {
+	pool := func(autoScaler bool, minNodes, maxNodes int) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"id":            "pool-1",
+				"node_quantity": 2,
+				"label":         "workers",
+				"plan":          "vc2-2c-4gb",
+				"tag":           "",
+				"auto_scaler":   autoScaler,
+				"min_nodes":     minNodes,
+				"max_nodes":     maxNodes,
+				"labels":        map[string]interface{}{},
+				"taints":        testNodePoolTaints(),
+			},
+		}
+	}
+
+	// The bounds are cleared when the auto scaler is disabled, so they are
+	// sent as 0 rather than left out
+	steps := []struct {
+		name     string
+		old, new []interface{}
+		expected map[string]interface{}
+	}{
+		{"enable", pool(false, 1, 1), pool(true, 2, 4), map[string]interface{}{"node_quantity": 2.0, "auto_scaler": true, "min_nodes": 2.0, "max_nodes": 4.0}},
+		{"disable", pool(true, 2, 4), pool(false, 2, 4), map[string]interface{}{"node_quantity": 2.0, "auto_scaler": false, "min_nodes": 0.0, "max_nodes": 0.0}},
+		{"enable again", pool(false, 0, 0), pool(true, 1, 3), map[string]interface{}{"node_quantity": 2.0, "auto_scaler": true, "min_nodes": 1.0, "max_nodes": 3.0}},
+	}
+
+	for _, step := range steps {
+		api := &fakeNodePoolAPI{}
+		if _, err := applyVKENodePoolChange(context.Background(), newFakeNodePoolClient(t, api), "cluster", step.old, step.new); err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.name, err)
+		}
+
+		req := api.updated["pool-1"]
+		for _, key := range []string{"tag", "labels", "taints"} {
+			delete(req, key)
+		}
+		if !reflect.DeepEqual(req, step.expected) {
+			t.Errorf("%s: expected update %+v, got %+v", step.name, step.expected, req)
+		}
+	}
+}
+
+func TestGetVKEKubeConfigRetriesUntilAvailable(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		if n < 3 {
+			fmt.Fprint(w, `{"kube_config":""}`)
+			return
+		}
+		fmt.Fprint(w, `{"kube_config":"a3ViZWNvbmZpZw=="}`)
+	}).govultrClient()
+
+	config, err := getVKEKubeConfig(context.Background(), client, "cluster", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if config != "a3ViZWNvbmZpZw==" {
+		t.Fatalf("unexpected kubeconfig %q", config)
+	}
+
+	if n := atomic.LoadInt32(&requests); n != 3 {
+		t.Fatalf("expected 3 requests, got %d", n)
+	}
+}
+
+func TestGetVKEKubeConfigDoesNotRetryErrors(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":"Invalid API token.","status":403}`)
+	}).govultrClient()
+
+	_, err := getVKEKubeConfig(context.Background(), client, "cluster", time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "Invalid API token") {
+		t.Fatalf("expected the API error, got %v", err)
+	}
+
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Fatalf("expected 1 request, got %d", n)
+	}
+}
+
+func TestResourceVultrKubernetesReadKeepsKubeConfigDuringUpgrade(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/kubernetes/clusters/cluster":
+			fmt.Fprint(w, `{"vke_cluster":{"id":"cluster","version":"v1.25.4+1","status":"pending"}}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"kubeconfig is not available","status":400}`)
+		}
+	})
+
+	d := resourceVultrKubernetes().TestResourceData()
+	d.SetId("cluster")
+	d.Set("include_kube_config", true)
+	d.Set("kube_config", "a3ViZWNvbmZpZw==")
+
+	if diags := resourceVultrKubernetesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("kube_config").(string) != "a3ViZWNvbmZpZw==" {
+		t.Fatalf("expected kubeconfig to be kept, got %q", d.Get("kube_config"))
+	}
+
+	if d.Get("version").(string) != "v1.25.4+1" {
+		t.Fatalf("unexpected version %q", d.Get("version"))
+	}
+}
+
+func TestResourceVultrKubernetesReadKubeConfigUnauthorized(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/kubernetes/clusters/cluster":
+			fmt.Fprint(w, `{"vke_cluster":{"id":"cluster","version":"v1.25.4+1","status":"pending"}}`)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":"Unauthorized IP address","status":403}`)
+		}
+	})
+
+	d := resourceVultrKubernetes().TestResourceData()
+	d.SetId("cluster")
+	d.Set("include_kube_config", true)
+
+	diags := resourceVultrKubernetesRead(context.Background(), d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "Unauthorized IP address") {
+		t.Fatalf("expected the kubeconfig error to be returned, got %v", diags)
+	}
+}
+
+func TestResourceVultrKubernetesReadWithoutKubeConfig(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/kubernetes/clusters/cluster" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"vke_cluster":{"id":"cluster","version":"v1.25.4+1","status":"active"}}`)
+	})
+
+	d := resourceVultrKubernetes().TestResourceData()
+	d.SetId("cluster")
+	d.Set("include_kube_config", false)
+	d.Set("kube_config", "a3ViZWNvbmZpZw==")
+	d.Set("client_key", "key")
+
+	if diags := resourceVultrKubernetesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("kube_config").(string) != "" || d.Get("client_key").(string) != "" {
+		t.Fatalf("expected kubeconfig and credentials to be cleared")
+	}
+}
+
+func TestResourceVultrKubernetesKubeConfigPath(t *testing.T) {
+	kubeConfig := base64.StdEncoding.EncodeToString([]byte("clusters:\n- cluster:\n    server: https://cluster.vultr-k8s.com:6443\nusers:\n- user: {}\n"))
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/kubernetes/clusters/cluster":
+			fmt.Fprint(w, `{"vke_cluster":{"id":"cluster","version":"v1.25.4+1","status":"active"}}`)
+		case "/v2/kubernetes/clusters/cluster/config":
+			fmt.Fprintf(w, `{"kube_config":%q}`, kubeConfig)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	path := filepath.Join(t.TempDir(), "config")
+	d := resourceVultrKubernetes().TestResourceData()
+	d.SetId("cluster")
+	d.Set("include_kube_config", true)
+	d.Set("kube_config_path", path)
+
+	if diags := resourceVultrKubernetesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected read not to write the kubeconfig, got %v", err)
+	}
+
+	if diags := writeVKEKubeConfigPath(d); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected the kubeconfig to be written: %v", err)
+	}
+}
+
+func TestResourceVultrKubernetesReadNodePoolsRaw(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/kubernetes/clusters/cluster" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"vke_cluster":{"id":"cluster","status":"active","node_pools":[{"id":"np-1","tag":"workers"},{"id":"np-2","tag":"batch"}]}}`)
+	})
+
+	d := resourceVultrKubernetes().TestResourceData()
+	d.SetId("cluster")
+	d.Set("include_kube_config", false)
+
+	if diags := resourceVultrKubernetesRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var pools []govultr.NodePool
+	if err := json.Unmarshal([]byte(d.Get("node_pools_raw").(string)), &pools); err != nil {
+		t.Fatalf("unexpected error decoding node_pools_raw: %v", err)
+	}
+	if len(pools) != 2 || pools[0].ID != "np-1" || pools[1].Tag != "batch" {
+		t.Fatalf("unexpected node_pools_raw %q", d.Get("node_pools_raw"))
+	}
+}
+
+func TestGetVKECluster(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/kubernetes/clusters/cluster" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"vke_cluster":{"id":"cluster","label":"tf-vke","status":"active","firewall_group_id":"fwg-1","ha_controlplanes":true,"node_pools":[{"id":"pool-1"}]}}`)
+	}).govultrClient()
+
+	cluster, err := getVKECluster(context.Background(), client, "cluster")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cluster.FirewallGroupID != "fwg-1" {
+		t.Errorf("expected firewall group fwg-1, got %q", cluster.FirewallGroupID)
+	}
+	if !cluster.HAControlPlanes {
+		t.Error("expected ha_controlplanes to be decoded")
+	}
+	if cluster.Label != "tf-vke" || len(cluster.NodePools) != 1 {
+		t.Errorf("unexpected cluster %+v", cluster.Cluster)
+	}
+}
+
+func TestCreateVKECluster(t *testing.T) {
+	var body map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v2/kubernetes/clusters" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("error decoding request: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"vke_cluster":{"id":"cluster","status":"pending","ha_controlplanes":true,"node_pools":[{"id":"pool-1"}]}}`)
+	}).govultrClient()
+
+	req := &vkeClusterReq{
+		Label:           "tf-vke",
+		Region:          "ewr",
+		Version:         "v1.25.4+1",
+		HAControlPlanes: true,
+		ClusterSubnet:   "10.10.0.0/16",
+		NodePools:       []vkeNodePoolReq{{NodePoolReq: govultr.NodePoolReq{Label: "np", Plan: "vc2-1c-2gb", NodeQuantity: 1}}},
+	}
+	cluster, err := createVKECluster(context.Background(), client, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if body["ha_controlplanes"] != true {
+		t.Errorf("expected ha_controlplanes to be sent, got %v", body["ha_controlplanes"])
+	}
+	if body["cluster_subnet"] != "10.10.0.0/16" {
+		t.Errorf("expected cluster_subnet to be sent, got %v", body["cluster_subnet"])
+	}
+	if _, ok := body["service_subnet"]; ok {
+		t.Errorf("expected an unset service_subnet to be left to the API, got %v", body["service_subnet"])
+	}
+	if cluster.ID != "cluster" || len(cluster.NodePools) != 1 {
+		t.Errorf("unexpected cluster %+v", cluster.Cluster)
+	}
+}
+
+func TestNodeMainIPs(t *testing.T) {
+	var requests int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path != "/v2/instances" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			if tag := r.URL.Query().Get("tag"); tag != "workers" {
+				t.Errorf("expected instances to be filtered by tag workers, got %q", tag)
+			}
+			fmt.Fprint(w, `{"instances":[{"id":"node-active","main_ip":"192.0.2.10"},{"id":"other","main_ip":"192.0.2.20"}],"meta":{"total":3,"links":{"next":"page-2","prev":""}}}`)
+		default:
+			fmt.Fprint(w, `{"instances":[{"id":"node-pending","main_ip":"0.0.0.0"}],"meta":{"total":3,"links":{"next":"","prev":""}}}`)
+		}
+	}).govultrClient()
+
+	ips := nodeMainIPs(context.Background(), client, "workers", []govultr.Node{
+		{ID: "node-active"},
+		{ID: "node-pending"},
+		{ID: "node-missing"},
+	})
+
+	if len(ips) != 1 || ips["node-active"] != "192.0.2.10" {
+		t.Fatalf("unexpected node IPs: %v", ips)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Fatalf("expected one request per page of instances, got %d", n)
+	}
+}
+
+func TestNodeMainIPsUntagged(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("tag") != "" {
+			fmt.Fprint(w, `{"instances":[],"meta":{"total":0,"links":{"next":"","prev":""}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"instances":[{"id":"node-active","main_ip":"192.0.2.10"}],"meta":{"total":1,"links":{"next":"","prev":""}}}`)
+	}).govultrClient()
+
+	ips := nodeMainIPs(context.Background(), client, "workers", []govultr.Node{{ID: "node-active"}})
+	if ips["node-active"] != "192.0.2.10" {
+		t.Fatalf("expected untagged nodes to be found, got %v", ips)
+	}
+}
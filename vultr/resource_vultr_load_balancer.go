@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -22,6 +23,7 @@ func resourceVultrLoadBalancer() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceVultrLoadBalancerCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -182,8 +184,17 @@ func resourceVultrLoadBalancer() *schema.Resource {
 							ValidateFunc: validation.StringInSlice([]string{"v4", "v6"}, false),
 						},
 						"source": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateLBFirewallSource,
+						},
+						"subnet": {
 							Type:     schema.TypeString,
-							Required: true,
+							Computed: true,
+						},
+						"subnet_size": {
+							Type:     schema.TypeInt,
+							Computed: true,
 						},
 						"id": {
 							Type:     schema.TypeString,
@@ -340,11 +351,14 @@ func resourceVultrLoadBalancerRead(ctx context.Context, d *schema.ResourceData,
 
 	var fwrList []map[string]interface{}
 	for _, rules := range lb.FirewallRules {
+		subnet, subnetSize := splitLBFirewallSource(rules.Source)
 		rule := map[string]interface{}{
-			"id":      rules.RuleID,
-			"source":  rules.Source,
-			"ip_type": rules.IPType,
-			"port":    rules.Port,
+			"id":          rules.RuleID,
+			"source":      rules.Source,
+			"subnet":      subnet,
+			"subnet_size": subnetSize,
+			"ip_type":     rules.IPType,
+			"port":        rules.Port,
 		}
 		fwrList = append(fwrList, rule)
 	}
@@ -440,6 +454,8 @@ func resourceVultrLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData
 		req.ForwardingRules = rules
 	}
 
+	// The API has no endpoints to add or remove a single load balancer
+	// firewall rule, so the full set is sent and replaced in place.
 	if d.HasChange("firewall_rules") {
 		_, newFWR := d.GetChange("firewall_rules")
 
@@ -495,6 +511,10 @@ func resourceVultrLoadBalancerUpdate(ctx context.Context, d *schema.ResourceData
 		return diag.Errorf("error updating load balancer generic info (%v): %v", d.Id(), err)
 	}
 
+	if _, err := waitForLBAvailable(ctx, d, "active", []string{"pending", "installing"}, "status", meta); err != nil {
+		return diag.Errorf("error while waiting for load balancer %v to be updated: %v", d.Id(), err)
+	}
+
 	return resourceVultrLoadBalancerRead(ctx, d, meta)
 }
 
@@ -577,6 +597,65 @@ func generateFirewallRules(rules interface{}) []govultr.LBFirewallRule {
 	return fwrMap
 }
 
+// resourceVultrLoadBalancerCustomizeDiff checks the health check settings that
+// depend on each other. The block is computed, so it is only checked when it
+// is set or changed rather than on every plan.
+func resourceVultrLoadBalancerCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && !d.HasChange("health_check") {
+		return nil
+	}
+
+	if _, ok := d.GetOk("health_check.0"); !ok {
+		return nil
+	}
+
+	return validateLBHealthCheck(d.Get("health_check.0.protocol").(string), d.Get("health_check.0.path").(string))
+}
+
+// validateLBHealthCheck rejects a path on TCP health checks, which only open
+// a connection to the port.
+func validateLBHealthCheck(protocol, path string) error {
+	if path != "" && protocol != "http" && protocol != "https" {
+		return fmt.Errorf("health_check path can only be set for http and https health checks, not %s", protocol)
+	}
+
+	return nil
+}
+
+// validateLBFirewallSource accepts an IP address, a CIDR block or
+// "cloudflare" as the source of a load balancer firewall rule.
+func validateLBFirewallSource(v interface{}, k string) ([]string, []error) {
+	source := v.(string)
+	if source == "cloudflare" || net.ParseIP(source) != nil {
+		return nil, nil
+	}
+
+	if _, _, err := net.ParseCIDR(source); err == nil {
+		return nil, nil
+	}
+
+	return nil, []error{fmt.Errorf("expected %s to be an IP address, a CIDR block or \"cloudflare\", got %q", k, source)}
+}
+
+// splitLBFirewallSource returns the subnet and subnet size of a firewall rule
+// source. A bare IP address is treated as a single host, "cloudflare" has
+// neither.
+func splitLBFirewallSource(source string) (string, int) {
+	if ip, ipNet, err := net.ParseCIDR(source); err == nil {
+		size, _ := ipNet.Mask.Size()
+		return ip.String(), size
+	}
+
+	if ip := net.ParseIP(source); ip != nil {
+		if ip.To4() != nil {
+			return ip.String(), 32
+		}
+		return ip.String(), 128
+	}
+
+	return "", 0
+}
+
 func generateHealthCheck(health interface{}) *govultr.HealthCheck {
 	k := health.([]interface{})
 	config := k[0].(map[string]interface{})
@@ -32,6 +32,109 @@ func TestAccResourceVultrLoadBalancer(t *testing.T) {
 	})
 }
 
+func TestAccResourceVultrLoadBalancerFirewallRules(t *testing.T) {
+	rLabel := acctest.RandomWithPrefix("tf-lb-rs-fw")
+
+	name := "vultr_load_balancer.foo"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckVultrLoadBalancerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVultrLoadBalancerConfigFirewallRules(rLabel, `
+					firewall_rules {
+						port    = 80
+						ip_type = "v4"
+						source  = "192.0.2.0/24"
+					}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "firewall_rules.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "firewall_rules.*", map[string]string{
+						"source":      "192.0.2.0/24",
+						"subnet":      "192.0.2.0",
+						"subnet_size": "24",
+					}),
+				),
+			},
+			{
+				Config: testAccVultrLoadBalancerConfigFirewallRules(rLabel, `
+					firewall_rules {
+						port    = 80
+						ip_type = "v4"
+						source  = "192.0.2.0/24"
+					}
+
+					firewall_rules {
+						port    = 80
+						ip_type = "v4"
+						source  = "cloudflare"
+					}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "firewall_rules.#", "2"),
+				),
+			},
+			{
+				Config: testAccVultrLoadBalancerConfigFirewallRules(rLabel, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "firewall_rules.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateLBFirewallSource(t *testing.T) {
+	for _, source := range []string{"cloudflare", "192.0.2.10", "192.0.2.0/24", "2001:db8::/32"} {
+		if _, errs := validateLBFirewallSource(source, "source"); len(errs) != 0 {
+			t.Errorf("expected %q to be valid, got %v", source, errs)
+		}
+	}
+
+	for _, source := range []string{"", "Cloudflare", "192.0.2.0/33", "example.com"} {
+		if _, errs := validateLBFirewallSource(source, "source"); len(errs) == 0 {
+			t.Errorf("expected %q to be invalid", source)
+		}
+	}
+}
+
+func TestValidateLBHealthCheck(t *testing.T) {
+	for _, protocol := range []string{"http", "https"} {
+		if err := validateLBHealthCheck(protocol, "/health"); err != nil {
+			t.Errorf("expected a path to be allowed for %s, got %v", protocol, err)
+		}
+	}
+
+	if err := validateLBHealthCheck("tcp", ""); err != nil {
+		t.Errorf("expected a tcp check without a path to be valid, got %v", err)
+	}
+
+	if err := validateLBHealthCheck("tcp", "/health"); err == nil {
+		t.Error("expected a path to be rejected for tcp")
+	}
+}
+
+func TestSplitLBFirewallSource(t *testing.T) {
+	tests := []struct {
+		source string
+		subnet string
+		size   int
+	}{
+		{"192.0.2.0/24", "192.0.2.0", 24},
+		{"192.0.2.10", "192.0.2.10", 32},
+		{"2001:db8::/32", "2001:db8::", 32},
+		{"2001:db8::1", "2001:db8::1", 128},
+		{"cloudflare", "", 0},
+	}
+
+	for _, tt := range tests {
+		subnet, size := splitLBFirewallSource(tt.source)
+		if subnet != tt.subnet || size != tt.size {
+			t.Errorf("splitLBFirewallSource(%q) = %q, %d, expected %q, %d", tt.source, subnet, size, tt.subnet, tt.size)
+		}
+	}
+}
+
 func TestAccResourceVultrLoadBalancerUpdateHealth(t *testing.T) {
 	rLabel := acctest.RandomWithPrefix("tf-lb-rs")
 
@@ -134,6 +237,22 @@ func testAccVultrLoadBalancerBase(label string) string {
 		}`, label)
 }
 
+func testAccVultrLoadBalancerConfigFirewallRules(label, rules string) string {
+	return fmt.Sprintf(`
+		resource "vultr_load_balancer" "foo" {
+			region   = "ewr"
+			label       = "%s"
+
+			forwarding_rules {
+				frontend_protocol = "http"
+				frontend_port     = 80
+				backend_protocol  = "http"
+				backend_port      = 80
+			}
+			%s
+		}`, label, rules)
+}
+
 func testAccVultrLoadBalancerConfigUpdateHealth(label string) string {
 	return fmt.Sprintf(`
 		resource "vultr_load_balancer" "foo" {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -20,6 +21,9 @@ func resourceVultrObjectStorage() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"cluster_id": {
 				Type:     schema.TypeInt,
@@ -78,7 +82,7 @@ func resourceVultrObjectStorageCreate(ctx context.Context, d *schema.ResourceDat
 
 	d.SetId(obj.ID)
 
-	if _, err = waitForObjAvailable(ctx, d, "active", []string{"pending"}, "status", meta); err != nil {
+	if _, err = waitForObjAvailable(ctx, d, "active", []string{"pending"}, "status", d.Timeout(schema.TimeoutCreate), meta); err != nil {
 		return diag.Errorf("error while waiting for Object Storage %s to be in a active state : %s", d.Id(), err)
 	}
 
@@ -90,6 +94,11 @@ func resourceVultrObjectStorageRead(ctx context.Context, d *schema.ResourceData,
 
 	obj, err := client.ObjectStorage.Get(ctx, d.Id())
 	if err != nil {
+		if strings.Contains(err.Error(), "\"status\":404") {
+			log.Printf("[WARN] Vultr Object Storage (%s) not found", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return diag.Errorf("error getting object storage account: %v", err)
 	}
 
@@ -130,7 +139,7 @@ func resourceVultrObjectStorageDelete(ctx context.Context, d *schema.ResourceDat
 	return nil
 }
 
-func waitForObjAvailable(ctx context.Context, d *schema.ResourceData, target string, pending []string, attribute string, meta interface{}) (interface{}, error) {
+func waitForObjAvailable(ctx context.Context, d *schema.ResourceData, target string, pending []string, attribute string, timeout time.Duration, meta interface{}) (interface{}, error) {
 	log.Printf(
 		"[INFO] Waiting for Object Storage (%s) to have %s of %s",
 		d.Id(), attribute, target)
@@ -139,7 +148,7 @@ func waitForObjAvailable(ctx context.Context, d *schema.ResourceData, target str
 		Pending:        pending,
 		Target:         []string{target},
 		Refresh:        newServerObjRefresh(ctx, d, meta, attribute),
-		Timeout:        60 * time.Minute,
+		Timeout:        timeout,
 		Delay:          10 * time.Second,
 		MinTimeout:     3 * time.Second,
 		NotFoundChecks: 60,
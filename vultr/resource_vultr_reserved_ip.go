@@ -2,7 +2,10 @@ package vultr
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -22,15 +25,25 @@ func resourceVultrReservedIP() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"region": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"region", "convert_from_ip"},
 			},
 			"ip_type": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ForceNew:     true,
 				ValidateFunc: validation.StringInSlice([]string{"v4", "v6"}, false),
+				ExactlyOneOf: []string{"ip_type", "convert_from_ip"},
+			},
+			"convert_from_ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPAddress,
 			},
 			"label": {
 				Type:     schema.TypeString,
@@ -57,21 +70,30 @@ func resourceVultrReservedIP() *schema.Resource {
 func resourceVultrReservedIPCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
-	req := &govultr.ReservedIPReq{
-		Region:     d.Get("region").(string),
-		IPType:     d.Get("ip_type").(string),
-		Label:      d.Get("label").(string),
-		InstanceID: d.Get("instance_id").(string),
-	}
-	rip, err := client.ReservedIP.Create(ctx, req)
-	if err != nil {
-		return diag.Errorf("error creating reserved IP: %v", err)
+	var rip *govultr.ReservedIP
+	if ip, convertOK := d.GetOk("convert_from_ip"); convertOK {
+		var err error
+		if rip, err = convertReservedIP(ctx, client, ip.(string), d.Get("label").(string)); err != nil {
+			return diag.Errorf("error converting IP %s to a reserved IP: %v", ip, err)
+		}
+	} else {
+		req := &govultr.ReservedIPReq{
+			Region:     d.Get("region").(string),
+			IPType:     d.Get("ip_type").(string),
+			Label:      d.Get("label").(string),
+			InstanceID: d.Get("instance_id").(string),
+		}
+		var err error
+		if rip, err = client.ReservedIP.Create(ctx, req); err != nil {
+			return diag.Errorf("error creating reserved IP: %v", err)
+		}
 	}
 
 	d.SetId(rip.ID)
 	log.Printf("[INFO] Reserved IP ID: %s", d.Id())
 
-	if a, attachedOK := d.GetOk("instance_id"); attachedOK {
+	// A converted IP stays attached to the instance it came from
+	if a, attachedOK := d.GetOk("instance_id"); attachedOK && a.(string) != rip.InstanceID {
 		if err := client.ReservedIP.Attach(ctx, d.Id(), a.(string)); err != nil {
 			return diag.Errorf("error attaching reserved IP: %v %v : %v", d.Id(), a.(string), err)
 		}
@@ -85,6 +107,11 @@ func resourceVultrReservedIPRead(ctx context.Context, d *schema.ResourceData, me
 
 	rip, err := client.ReservedIP.Get(ctx, d.Id())
 	if err != nil {
+		if strings.Contains(err.Error(), "\"status\":404") {
+			log.Printf("[WARN] Removing Reserved IP (%s) because it is gone", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return diag.Errorf("error getting Reserved IPs: %v", err)
 	}
 
@@ -149,3 +176,44 @@ func resourceVultrReservedIPDelete(ctx context.Context, d *schema.ResourceData,
 
 	return nil
 }
+
+// convertReservedIP turns an instance IP into a reserved IP. An IP that is
+// already reserved is adopted instead, so a failed apply can be retried.
+func convertReservedIP(ctx context.Context, client *govultr.Client, ip, label string) (*govultr.ReservedIP, error) {
+	rip, err := client.ReservedIP.Convert(ctx, &govultr.ReservedIPConvertReq{IPAddress: ip, Label: label})
+	if err == nil {
+		return rip, nil
+	}
+
+	existing, listErr := findReservedIPBySubnet(ctx, client, ip)
+	if listErr != nil {
+		return nil, fmt.Errorf("%v, and listing reserved IPs failed: %v", err, listErr)
+	}
+	if existing == nil {
+		return nil, err
+	}
+
+	log.Printf("[INFO] IP %s is already reserved as %s", ip, existing.ID)
+	return existing, nil
+}
+
+func findReservedIPBySubnet(ctx context.Context, client *govultr.Client, ip string) (*govultr.ReservedIP, error) {
+	options := &govultr.ListOptions{}
+	for {
+		rips, meta, err := client.ReservedIP.List(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range rips {
+			if net.ParseIP(rips[i].Subnet).Equal(net.ParseIP(ip)) {
+				return &rips[i], nil
+			}
+		}
+
+		if meta == nil || meta.Links.Next == "" {
+			return nil, nil
+		}
+		options.Cursor = meta.Links.Next
+	}
+}
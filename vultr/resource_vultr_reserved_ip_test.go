@@ -3,6 +3,7 @@ package vultr
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -32,6 +33,11 @@ func TestAccVultrReservedIPIPv4(t *testing.T) {
 					resource.TestCheckResourceAttrSet("vultr_reserved_ip.foo", "subnet_size"),
 				),
 			},
+			{
+				ResourceName:      "vultr_reserved_ip.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 			{
 				Config: testAccVultrReservedIPConfigAttach(rServerLabel, rLabelUpdated, ipType),
 				Check: resource.ComposeTestCheckFunc(
@@ -217,3 +223,29 @@ func testAccVultrReservedIPConfigAttach(rServerLabel, label, ipType string) stri
        instance_id = "${vultr_instance.ip.id}"
    }`, rServerLabel, label, ipType)
 }
+
+func TestConvertReservedIPAlreadyReserved(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/reserved-ips/convert":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":"IP address is already reserved","status":400}`)
+		case "/v2/reserved-ips":
+			fmt.Fprint(w, `{"reserved_ips":[{"id":"other","subnet":"192.0.2.2"},{"id":"rip","subnet":"192.0.2.1","instance_id":"instance"}],"meta":{"links":{"next":""}}}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}).govultrClient()
+
+	rip, err := convertReservedIP(context.Background(), client, "192.0.2.1", "label")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rip.ID != "rip" || rip.InstanceID != "instance" {
+		t.Fatalf("expected to adopt reserved IP rip, got %+v", rip)
+	}
+
+	if _, err := convertReservedIP(context.Background(), client, "192.0.2.3", "label"); err == nil {
+		t.Fatalf("expected an error for an IP that is not reserved")
+	}
+}
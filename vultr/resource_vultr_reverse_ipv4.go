@@ -2,10 +2,14 @@ package vultr
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vultr/govultr/v2"
 )
 
@@ -14,6 +18,9 @@ func resourceVultrReverseIPV4() *schema.Resource {
 		CreateContext: resourceVultrReverseIPV4Create,
 		ReadContext:   resourceVultrReverseIPV4Read,
 		DeleteContext: resourceVultrReverseIPV4Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVultrReverseIPV4Import,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"instance_id": {
@@ -22,9 +29,10 @@ func resourceVultrReverseIPV4() *schema.Resource {
 				ForceNew: true,
 			},
 			"ip": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPv4Address,
 			},
 			"reverse": {
 				Type:     schema.TypeString,
@@ -48,6 +56,16 @@ func resourceVultrReverseIPV4Create(ctx context.Context, d *schema.ResourceData,
 
 	instanceID := d.Get("instance_id").(string)
 	ip := d.Get("ip").(string)
+
+	// The API error for an IP of another instance does not say what is wrong
+	ipv4, err := getInstanceIPv4(ctx, client, instanceID, ip)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if ipv4 == nil {
+		return diag.Errorf("IPv4 address %s does not belong to instance %s", ip, instanceID)
+	}
+
 	req := &govultr.ReverseIP{
 		IP:      ip,
 		Reverse: d.Get("reverse").(string),
@@ -70,34 +88,23 @@ func resourceVultrReverseIPV4Read(ctx context.Context, d *schema.ResourceData, m
 
 	instanceID := d.Get("instance_id").(string)
 
-	var ReverseIPV4 *govultr.IPv4
-
-	options := &govultr.ListOptions{}
-	for {
-		ReverseIPV4s, meta, err := client.Instance.ListIPv4(ctx, instanceID, options)
-		if err != nil {
-			return diag.Errorf("error getting reverse IPv4s: %v, %v", err, instanceID)
-		}
-
-		for _, v := range ReverseIPV4s {
-
-			if v.IP == d.Id() {
-				ReverseIPV4 = &v
-				break
-			}
-		}
-
-		if ReverseIPV4 != nil {
-			break
-		}
-
-		if meta.Links.Next == "" {
-			return diag.Errorf("error getting reverse IPv4s: %v, %v", err, instanceID)
+	ReverseIPV4, err := getInstanceIPv4(ctx, client, instanceID, d.Id())
+	if err != nil {
+		if isInstanceNotFound(err) {
+			log.Printf("[WARN] Removing reverse IPv4 (%s) because instance %s is gone", d.Id(), instanceID)
+			d.SetId("")
+			return nil
 		}
+		return diag.FromErr(err)
+	}
 
-		options.Cursor = meta.Links.Next
+	if ReverseIPV4 == nil {
+		log.Printf("[WARN] Removing reverse IPv4 (%s) because it is gone", d.Id())
+		d.SetId("")
+		return nil
 	}
 
+	d.Set("instance_id", instanceID)
 	d.Set("ip", ReverseIPV4.IP)
 	d.Set("reverse", ReverseIPV4.Reverse)
 	d.Set("netmask", ReverseIPV4.Netmask)
@@ -118,3 +125,37 @@ func resourceVultrReverseIPV4Delete(ctx context.Context, d *schema.ResourceData,
 
 	return nil
 }
+
+func resourceVultrReverseIPV4Import(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 2 || net.ParseIP(parts[1]).To4() == nil {
+		return nil, fmt.Errorf(`invalid import format, expected "instanceID:ip"`)
+	}
+
+	d.SetId(parts[1])
+	d.Set("instance_id", parts[0])
+	return []*schema.ResourceData{d}, nil
+}
+
+// getInstanceIPv4 returns the IPv4 address ip of the instance, or nil when the
+// address does not belong to it.
+func getInstanceIPv4(ctx context.Context, client *govultr.Client, instanceID, ip string) (*govultr.IPv4, error) {
+	options := &govultr.ListOptions{}
+	for {
+		ipv4s, meta, err := client.Instance.ListIPv4(ctx, instanceID, options)
+		if err != nil {
+			return nil, fmt.Errorf("error getting IPv4s for instance %s: %w", instanceID, err)
+		}
+
+		for i := range ipv4s {
+			if ipv4s[i].IP == ip {
+				return &ipv4s[i], nil
+			}
+		}
+
+		if meta == nil || meta.Links == nil || meta.Links.Next == "" {
+			return nil, nil
+		}
+		options.Cursor = meta.Links.Next
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -34,10 +36,38 @@ func TestAccVultrReverseIPV4Basic(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "reverse", reverse),
 				),
 			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[name]
+					return fmt.Sprintf("%s:%s", rs.Primary.Attributes["instance_id"], rs.Primary.ID), nil
+				},
+			},
 		},
 	})
 }
 
+func TestResourceVultrReverseIPV4CreateForeignIP(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v2/instances/instance/ipv4" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		fmt.Fprint(w, `{"ipv4s":[{"ip":"192.0.2.10","reverse":"192.0.2.10.vultrusercontent.com"}],"meta":{"total":1,"links":{"next":"","prev":""}}}`)
+	})
+
+	d := resourceVultrReverseIPV4().TestResourceData()
+	d.Set("instance_id", "instance")
+	d.Set("ip", "192.0.2.20")
+	d.Set("reverse", "host.example.com")
+
+	diags := resourceVultrReverseIPV4Create(context.Background(), d, client)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "IPv4 address 192.0.2.20 does not belong to instance instance") {
+		t.Fatalf("expected an ownership error, got %v", diags)
+	}
+}
+
 func testAccCheckVultrReverseIPV4Destroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "vultr_reverse_ipv4" {
@@ -2,10 +2,14 @@ package vultr
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vultr/govultr/v2"
 )
 
@@ -14,6 +18,9 @@ func resourceVultrReverseIPV6() *schema.Resource {
 		CreateContext: resourceVultrReverseIPV6Create,
 		ReadContext:   resourceVultrReverseIPV6Read,
 		DeleteContext: resourceVultrReverseIPV6Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVultrReverseIPV6Import,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"instance_id": {
@@ -22,9 +29,14 @@ func resourceVultrReverseIPV6() *schema.Resource {
 				ForceNew: true,
 			},
 			"ip": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPv6Address,
+				// The API returns the address in canonical form
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return canonicalIPv6(old) == canonicalIPv6(new)
+				},
 			},
 			"reverse": {
 				Type:     schema.TypeString,
@@ -49,7 +61,7 @@ func resourceVultrReverseIPV6Create(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("error creating reverse IPv6: %v", err)
 	}
 
-	d.SetId(ip)
+	d.SetId(canonicalIPv6(ip))
 
 	return resourceVultrReverseIPV6Read(ctx, d, meta)
 }
@@ -59,16 +71,23 @@ func resourceVultrReverseIPV6Read(ctx context.Context, d *schema.ResourceData, m
 
 	instanceID := d.Get("instance_id").(string)
 
-	reverseIPV6 := &govultr.ReverseIP{}
+	var reverseIPV6 *govultr.ReverseIP
 
 	reverseIPv6s, err := client.Instance.ListReverseIPv6(ctx, instanceID)
 	if err != nil {
-		return diag.Errorf("error getting reverse IPv4s: %v, %v", err, instanceID)
+		if isInstanceNotFound(err) {
+			log.Printf("[WARN] Removing reverse IPv6 (%s) because instance %s is gone", d.Id(), instanceID)
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting reverse IPv6s for instance %s: %v", instanceID, err)
 	}
 
-	for _, v := range reverseIPv6s {
-		if v.IP == d.Id() {
-			reverseIPV6 = &v
+	// IDs saved by older versions may not be in canonical form
+	id := canonicalIPv6(d.Id())
+	for i := range reverseIPv6s {
+		if canonicalIPv6(reverseIPv6s[i].IP) == id {
+			reverseIPV6 = &reverseIPv6s[i]
 			break
 		}
 	}
@@ -79,6 +98,8 @@ func resourceVultrReverseIPV6Read(ctx context.Context, d *schema.ResourceData, m
 		return nil
 	}
 
+	d.SetId(id)
+	d.Set("instance_id", instanceID)
 	d.Set("ip", reverseIPV6.IP)
 	d.Set("reverse", reverseIPV6.Reverse)
 
@@ -97,3 +118,24 @@ func resourceVultrReverseIPV6Delete(ctx context.Context, d *schema.ResourceData,
 
 	return nil
 }
+
+func resourceVultrReverseIPV6Import(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// The instance ID is a UUID, so the first colon separates it from the IP
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if len(parts) != 2 || net.ParseIP(parts[1]) == nil {
+		return nil, fmt.Errorf(`invalid import format, expected "instanceID:ip"`)
+	}
+
+	d.SetId(canonicalIPv6(parts[1]))
+	d.Set("instance_id", parts[0])
+	return []*schema.ResourceData{d}, nil
+}
+
+// canonicalIPv6 returns ip in the compressed form used by the API, e.g.
+// 2001:db8::1 for 2001:0db8:0:0:0:0:0:1.
+func canonicalIPv6(ip string) string {
+	if parsed := net.ParseIP(ip); parsed != nil {
+		return parsed.String()
+	}
+	return ip
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -33,10 +34,49 @@ func TestAccVultrReverseIPV6Basic(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "reverse", reverse),
 				),
 			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources[name]
+					return fmt.Sprintf("%s:%s", rs.Primary.Attributes["instance_id"], rs.Primary.ID), nil
+				},
+			},
 		},
 	})
 }
 
+func TestResourceVultrReverseIPV6Read(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/instances/instance/ipv6/reverse" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"reverse_ipv6s":[{"ip":"2001:db8::1","reverse":"host.example.com"}]}`)
+	})
+
+	d := resourceVultrReverseIPV6().TestResourceData()
+	d.SetId("2001:0db8:0:0:0:0:0:1")
+	d.Set("instance_id", "instance")
+	if diags := resourceVultrReverseIPV6Read(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Get("reverse").(string) != "host.example.com" {
+		t.Fatalf("expected the reverse DNS record to be read, got %q", d.Get("reverse"))
+	}
+	if d.Id() != "2001:db8::1" {
+		t.Fatalf("expected the ID to be in canonical form, got %q", d.Id())
+	}
+
+	d.SetId("2001:db8::2")
+	if diags := resourceVultrReverseIPV6Read(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("expected a missing reverse DNS record to be removed from state, got ID %q", d.Id())
+	}
+}
+
 func testAccCheckVultrReverseIPV6Destroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "vultr_reverse_ipv6" {
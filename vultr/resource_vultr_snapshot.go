@@ -20,6 +20,9 @@ func resourceVultrSnapshot() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"instance_id": {
@@ -72,7 +75,7 @@ func resourceVultrSnapshotCreate(ctx context.Context, d *schema.ResourceData, me
 
 	d.SetId(snapshot.ID)
 
-	if _, err = waitForSnapshot(ctx, d, "complete", []string{"pending"}, "status", meta); err != nil {
+	if _, err = waitForSnapshot(ctx, d, "complete", []string{"pending"}, "status", d.Timeout(schema.TimeoutCreate), meta); err != nil {
 		return diag.Errorf(
 			"error while waiting for Snapshot %s to be completed: %s", d.Id(), err)
 	}
@@ -117,7 +120,7 @@ func resourceVultrSnapshotDelete(ctx context.Context, d *schema.ResourceData, me
 	return nil
 }
 
-func waitForSnapshot(ctx context.Context, d *schema.ResourceData, target string, pending []string, attribute string, meta interface{}) (interface{}, error) {
+func waitForSnapshot(ctx context.Context, d *schema.ResourceData, target string, pending []string, attribute string, timeout time.Duration, meta interface{}) (interface{}, error) {
 	log.Printf(
 		"[INFO] Waiting for Snapshot (%s) to have %s of %s",
 		d.Id(), attribute, target)
@@ -126,7 +129,7 @@ func waitForSnapshot(ctx context.Context, d *schema.ResourceData, target string,
 		Pending:        pending,
 		Target:         []string{target},
 		Refresh:        newSnapStateRefresh(d, meta),
-		Timeout:        60 * time.Minute,
+		Timeout:        timeout,
 		Delay:          10 * time.Second,
 		MinTimeout:     3 * time.Second,
 		NotFoundChecks: 60,
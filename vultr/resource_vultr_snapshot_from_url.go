@@ -5,6 +5,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/vultr/govultr/v2"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -17,6 +18,9 @@ func resourceVultrSnapshotFromURL() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"url": {
@@ -65,6 +69,12 @@ func resourceVultrSnapshotFromURLCreate(ctx context.Context, d *schema.ResourceD
 	}
 
 	d.SetId(snapshot.ID)
+
+	if _, err = waitForSnapshot(ctx, d, "complete", []string{"pending"}, "status", d.Timeout(schema.TimeoutCreate), meta); err != nil {
+		return diag.Errorf(
+			"error while waiting for Snapshot %s to be completed: %s", d.Id(), err)
+	}
+
 	log.Printf("[INFO] Snapshot ID: %s", d.Id())
 
 	return resourceVultrSnapshotRead(ctx, d, meta)
@@ -3,6 +3,7 @@ package vultr
 import (
 	"context"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -28,6 +29,9 @@ func resourceVultrSSHKey() *schema.Resource {
 			"ssh_key": {
 				Type:     schema.TypeString,
 				Required: true,
+				StateFunc: func(val interface{}) string {
+					return strings.TrimSpace(val.(string))
+				},
 			},
 
 			"date_created": {
@@ -42,7 +46,7 @@ func resourceVultrSSHKeyCreate(ctx context.Context, d *schema.ResourceData, meta
 	client := meta.(*Client).govultrClient()
 	sshReq := &govultr.SSHKeyReq{
 		Name:   d.Get("name").(string),
-		SSHKey: d.Get("ssh_key").(string),
+		SSHKey: strings.TrimSpace(d.Get("ssh_key").(string)),
 	}
 
 	key, err := client.SSHKey.Create(ctx, sshReq)
@@ -81,7 +85,7 @@ func resourceVultrSSHKeyUpdate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	if d.HasChange("ssh_key") {
-		key.SSHKey = d.Get("ssh_key").(string)
+		key.SSHKey = strings.TrimSpace(d.Get("ssh_key").(string))
 	}
 
 	log.Printf("[INFO] Updating SSH Key: %s", d.Id())
@@ -86,6 +86,30 @@ func testAccCheckVultrSSHKeyExists(n string) resource.TestCheckFunc {
 	}
 }
 
+func TestAccVultrSSHKeyTrailingNewline(t *testing.T) {
+	rInt := acctest.RandInt()
+	rSSH, _, err := acctest.RandSSHKeyPair("foobar")
+
+	if err != nil {
+		t.Fatalf("Error generating test SSH key pair: %s", err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckVultrSSHKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVultrSSHKeyConfigTrailingNewline(rInt, rSSH),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVultrSSHKeyExists("vultr_ssh_key.foo"),
+					resource.TestCheckResourceAttr("vultr_ssh_key.foo", "ssh_key", rSSH),
+				),
+			},
+		},
+	})
+}
+
 func testAccVultrSSHKeyConfigBasic(rInt int, rSSH string) string {
 	return fmt.Sprintf(`
 		resource "vultr_ssh_key" "foo" {
@@ -103,3 +127,12 @@ func testAccVultrSSHKeyConfigUpdate(rInt int, rSSH string) string {
 		}
 	`, rInt, rSSH)
 }
+
+func testAccVultrSSHKeyConfigTrailingNewline(rInt int, rSSH string) string {
+	return fmt.Sprintf(`
+		resource "vultr_ssh_key" "foo" {
+			name       = "foo-%d"
+			ssh_key = "%s\n"
+		}
+	`, rInt, rSSH)
+}
@@ -2,6 +2,7 @@ package vultr
 
 import (
 	"context"
+	"encoding/base64"
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -28,14 +29,13 @@ func resourceVultrStartupScript() *schema.Resource {
 			"script": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validation.StringIsBase64,
+				ValidateFunc: validation.StringIsNotEmpty,
 			},
 			"type": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Default:      "boot",
 				ValidateFunc: validation.StringInSlice([]string{"boot", "pxe"}, false),
-				ForceNew:     true,
 			},
 			"date_created": {
 				Type:     schema.TypeString,
@@ -54,7 +54,7 @@ func resourceVultrStartupScriptCreate(ctx context.Context, d *schema.ResourceDat
 
 	scriptReq := &govultr.StartupScriptReq{
 		Name:   d.Get("name").(string),
-		Script: d.Get("script").(string),
+		Script: base64.StdEncoding.EncodeToString([]byte(d.Get("script").(string))),
 		Type:   d.Get("type").(string),
 	}
 
@@ -77,8 +77,15 @@ func resourceVultrStartupScriptRead(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("error getting startup script: %v", err)
 	}
 
+	// The API stores scripts base64 encoded, keep the plaintext in state so
+	// diffs stay readable.
+	decoded, err := base64.StdEncoding.DecodeString(script.Script)
+	if err != nil {
+		return diag.Errorf("error decoding startup script (%s): %v", d.Id(), err)
+	}
+
 	d.Set("name", script.Name)
-	d.Set("script", script.Script)
+	d.Set("script", string(decoded))
 	d.Set("type", script.Type)
 	d.Set("date_created", script.DateCreated)
 	d.Set("date_modified", script.DateModified)
@@ -93,7 +100,7 @@ func resourceVultrStartupScriptUpdate(ctx context.Context, d *schema.ResourceDat
 		scriptReq := &govultr.StartupScriptReq{
 			Name:   d.Get("name").(string),
 			Type:   d.Get("type").(string),
-			Script: d.Get("script").(string),
+			Script: base64.StdEncoding.EncodeToString([]byte(d.Get("script").(string))),
 		}
 
 		log.Printf("[INFO] Updating startup script: %s", d.Id())
@@ -25,7 +25,7 @@ func TestAccVultrStartupScriptBasic(t *testing.T) {
 					testAccCheckVultrStartupScriptExists("vultr_startup_script.foo"),
 					resource.TestCheckResourceAttr("vultr_startup_script.foo", "name", rName),
 					resource.TestCheckResourceAttr("vultr_startup_script.foo", "type", "pxe"),
-					resource.TestCheckResourceAttrSet("vultr_startup_script.foo", "script"),
+					resource.TestCheckResourceAttr("vultr_startup_script.foo", "script", "#!/bin/bash\necho hello world > /root/hello"),
 					resource.TestCheckResourceAttrSet("vultr_startup_script.foo", "date_created"),
 					resource.TestCheckResourceAttrSet("vultr_startup_script.foo", "date_modified"),
 				),
@@ -36,7 +36,7 @@ func TestAccVultrStartupScriptBasic(t *testing.T) {
 					testAccCheckVultrStartupScriptExists("vultr_startup_script.foo"),
 					resource.TestCheckResourceAttr("vultr_startup_script.foo", "name", rName),
 					resource.TestCheckResourceAttr("vultr_startup_script.foo", "type", "boot"),
-					resource.TestCheckResourceAttrSet("vultr_startup_script.foo", "script"),
+					resource.TestCheckResourceAttr("vultr_startup_script.foo", "script", "#!/bin/bash\necho hello terraform > /root/hello"),
 					resource.TestCheckResourceAttrSet("vultr_startup_script.foo", "date_created"),
 					resource.TestCheckResourceAttrSet("vultr_startup_script.foo", "date_modified"),
 				),
@@ -92,7 +92,7 @@ func testAccVultrStartupScriptConfigBasic(rName string) string {
 		resource "vultr_startup_script" "foo" {
 			name = "%s"
 			type = "pxe"
-			script = "IyEvYmluL2Jhc2hcbmVjaG8gaGVsbG8gd29ybGQgPiAvcm9vdC9oZWxsbw=="
+			script = "#!/bin/bash\necho hello world > /root/hello"
 		}
 	`, rName)
 }
@@ -102,7 +102,7 @@ func testAccVultrStartupScriptConfigUpdate(rName string) string {
 		resource "vultr_startup_script" "foo" {
 			name = "%s"
 			type = "boot"
-			script = "IyEvYmluL2Jhc2hcbmVjaG8gaGVsbG8gd29ybGQgPiAvcm9vdC9oZWxsbw=="
+			script = "#!/bin/bash\necho hello terraform > /root/hello"
 		}
 	`, rName)
 }
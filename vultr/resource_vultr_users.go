@@ -2,7 +2,10 @@ package vultr
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -31,6 +34,8 @@ func resourceVultrUsers() *schema.Resource {
 				Type:      schema.TypeString,
 				Sensitive: true,
 				Required:  true,
+				// Only a hash is kept in state so the plaintext password is never persisted
+				StateFunc: hashUserPassword,
 			},
 			"api_enabled": {
 				Type:     schema.TypeBool,
@@ -43,8 +48,9 @@ func resourceVultrUsers() *schema.Resource {
 				Optional: true,
 			},
 			"api_key": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
 			},
 		},
 	}
@@ -87,6 +93,11 @@ func resourceVultrUsersRead(ctx context.Context, d *schema.ResourceData, meta in
 
 	user, err := client.User.Get(ctx, d.Id())
 	if err != nil {
+		if strings.Contains(err.Error(), "\"status\":404") {
+			log.Printf("[WARN] Vultr user (%s) not found", d.Id())
+			d.SetId("")
+			return nil
+		}
 		return diag.Errorf("error getting user: %v", err)
 	}
 
@@ -150,3 +161,8 @@ func resourceVultrUsersDelete(ctx context.Context, d *schema.ResourceData, meta
 	}
 	return nil
 }
+
+func hashUserPassword(v interface{}) string {
+	sum := sha256.Sum256([]byte(v.(string)))
+	return hex.EncodeToString(sum[:])
+}
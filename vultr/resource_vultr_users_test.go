@@ -85,8 +85,16 @@ func TestAccVultrUserBase(t *testing.T) {
 						"vultr_user.admin", "acl.10", "alerts"),
 					resource.TestCheckResourceAttr(
 						"vultr_user.admin", "api_enabled", "false"),
+					resource.TestCheckResourceAttr(
+						"vultr_user.admin", "password", hashUserPassword("password")),
 				),
 			},
+			{
+				ResourceName:            "vultr_user.admin",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password", "api_key"},
+			},
 		},
 	})
 }
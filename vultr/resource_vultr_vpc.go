@@ -2,6 +2,7 @@ package vultr
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strings"
 
@@ -11,6 +12,8 @@ import (
 	"github.com/vultr/govultr/v2"
 )
 
+// resourceVultrVPC manages a VPC. Instances join a VPC through their own
+// vpc_ids attribute, so the VPC itself carries no attachment state.
 func resourceVultrVPC() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceVultrVPCCreate,
@@ -45,6 +48,10 @@ func resourceVultrVPC() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"cidr": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"date_created": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -91,6 +98,7 @@ func resourceVultrVPCRead(ctx context.Context, d *schema.ResourceData, meta inte
 	d.Set("description", vpc.Description)
 	d.Set("v4_subnet", vpc.V4Subnet)
 	d.Set("v4_subnet_mask", vpc.V4SubnetMask)
+	d.Set("cidr", fmt.Sprintf("%s/%d", vpc.V4Subnet, vpc.V4SubnetMask))
 	d.Set("date_created", vpc.DateCreated)
 
 	return nil
@@ -99,8 +107,11 @@ func resourceVultrVPCRead(ctx context.Context, d *schema.ResourceData, meta inte
 func resourceVultrVPCUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client).govultrClient()
 
-	if err := client.VPC.Update(ctx, d.Id(), d.Get("description").(string)); err != nil {
-		return diag.Errorf("error updating VPC: %v", err)
+	if d.HasChange("description") {
+		log.Printf("[INFO] Updating VPC: %s", d.Id())
+		if err := client.VPC.Update(ctx, d.Id(), d.Get("description").(string)); err != nil {
+			return diag.Errorf("error updating VPC: %v", err)
+		}
 	}
 
 	return resourceVultrVPCRead(ctx, d, meta)
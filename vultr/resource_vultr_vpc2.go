@@ -0,0 +1,192 @@
+package vultr
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceVultrVPC2 manages a VPC 2.0 network. Unlike a VPC, instances are
+// attached through the network itself, so it owns the list of attached nodes.
+func resourceVultrVPC2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVultrVPC2Create,
+		ReadContext:   resourceVultrVPC2Read,
+		UpdateContext: resourceVultrVPC2Update,
+		DeleteContext: resourceVultrVPC2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			"ip_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "v4",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"v4"}, false),
+			},
+			"ip_block": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPv4Address,
+				RequiredWith: []string{"prefix_length"},
+			},
+			"prefix_length": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(8, 30),
+				RequiredWith: []string{"ip_block"},
+			},
+			"node_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"date_created": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVultrVPC2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).govultrClient()
+
+	req := &vpc2Req{
+		Region:       d.Get("region").(string),
+		Description:  d.Get("description").(string),
+		IPType:       d.Get("ip_type").(string),
+		IPBlock:      d.Get("ip_block").(string),
+		PrefixLength: d.Get("prefix_length").(int),
+	}
+
+	vpc, err := createVPC2(ctx, client, req)
+	if err != nil {
+		return diag.Errorf("error creating VPC 2.0: %v", err)
+	}
+
+	d.SetId(vpc.ID)
+	log.Printf("[INFO] VPC 2.0 ID: %s", d.Id())
+
+	if nodeIDs, nodesOK := d.GetOk("node_ids"); nodesOK {
+		var attach []string
+		for _, v := range nodeIDs.(*schema.Set).List() {
+			attach = append(attach, v.(string))
+		}
+		if err := updateVPC2Nodes(ctx, client, d.Id(), "attach", attach); err != nil {
+			return diag.Errorf("error attaching nodes to VPC 2.0 (%s): %v", d.Id(), err)
+		}
+	}
+
+	return resourceVultrVPC2Read(ctx, d, meta)
+}
+
+func resourceVultrVPC2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).govultrClient()
+
+	vpc, err := getVPC2(ctx, client, d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "\"status\":404") {
+			log.Printf("[WARN] Vultr VPC 2.0 (%s) not found", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error getting VPC 2.0 (%s): %v", d.Id(), err)
+	}
+
+	nodes, err := listVPC2Nodes(ctx, client, d.Id())
+	if err != nil {
+		return diag.Errorf("error getting nodes of VPC 2.0 (%s): %v", d.Id(), err)
+	}
+
+	var nodeIDs []string
+	for _, n := range nodes {
+		nodeIDs = append(nodeIDs, n.ID)
+	}
+
+	d.Set("region", vpc.Region)
+	d.Set("description", vpc.Description)
+	// The API doesn't return the IP type, v4 is the only one it supports
+	d.Set("ip_type", "v4")
+	d.Set("ip_block", vpc.IPBlock)
+	d.Set("prefix_length", vpc.PrefixLength)
+	d.Set("date_created", vpc.DateCreated)
+	if err := d.Set("node_ids", nodeIDs); err != nil {
+		return diag.Errorf("error setting `node_ids`: %v", err)
+	}
+
+	return nil
+}
+
+func resourceVultrVPC2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).govultrClient()
+
+	if d.HasChange("description") {
+		log.Printf("[INFO] Updating VPC 2.0: %s", d.Id())
+		if err := updateVPC2(ctx, client, d.Id(), d.Get("description").(string)); err != nil {
+			return diag.Errorf("error updating VPC 2.0 (%s): %v", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("node_ids") {
+		oldNodes, newNodes := d.GetChange("node_ids")
+		attach, detach := networkIDChanges(oldNodes.(*schema.Set), newNodes.(*schema.Set))
+
+		if len(detach) != 0 {
+			log.Printf("[INFO] Detaching nodes %v from VPC 2.0: %s", detach, d.Id())
+			if err := updateVPC2Nodes(ctx, client, d.Id(), "detach", detach); err != nil {
+				return diag.Errorf("error detaching nodes from VPC 2.0 (%s): %v", d.Id(), err)
+			}
+		}
+		if len(attach) != 0 {
+			log.Printf("[INFO] Attaching nodes %v to VPC 2.0: %s", attach, d.Id())
+			if err := updateVPC2Nodes(ctx, client, d.Id(), "attach", attach); err != nil {
+				return diag.Errorf("error attaching nodes to VPC 2.0 (%s): %v", d.Id(), err)
+			}
+		}
+	}
+
+	return resourceVultrVPC2Read(ctx, d, meta)
+}
+
+func resourceVultrVPC2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Client).govultrClient()
+
+	// The network cannot be deleted while instances are attached to it
+	if nodeIDs, nodesOK := d.GetOk("node_ids"); nodesOK {
+		var detach []string
+		for _, v := range nodeIDs.(*schema.Set).List() {
+			detach = append(detach, v.(string))
+		}
+		if err := updateVPC2Nodes(ctx, client, d.Id(), "detach", detach); err != nil {
+			return diag.Errorf("error detaching nodes from VPC 2.0 (%s): %v", d.Id(), err)
+		}
+	}
+
+	log.Printf("[INFO] Deleting VPC 2.0: %s", d.Id())
+	if err := deleteVPC2(ctx, client, d.Id()); err != nil {
+		return diag.Errorf("error destroying VPC 2.0 (%s): %v", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package vultr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccVultrVPC2(t *testing.T) {
+	rDesc := acctest.RandomWithPrefix("tf-vpc2-rs")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckVultrVPC2Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVultrVPC2Config(rDesc),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vultr_vpc2.foo", "description", rDesc),
+					resource.TestCheckResourceAttr("vultr_vpc2.foo", "ip_block", "10.99.0.0"),
+					resource.TestCheckResourceAttr("vultr_vpc2.foo", "prefix_length", "24"),
+					resource.TestCheckResourceAttr("vultr_vpc2.foo", "node_ids.#", "1"),
+					resource.TestCheckResourceAttrSet("vultr_vpc2.foo", "date_created"),
+				),
+			},
+			{
+				ResourceName:      "vultr_vpc2.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceVultrVPC2UpdateNodes(t *testing.T) {
+	var mu sync.Mutex
+	var attached, detached []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/vpc2/vpc/nodes/attach", "/v2/vpc2/vpc/nodes/detach":
+			var req vpc2NodesReq
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("unexpected error decoding request: %v", err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if r.URL.Path == "/v2/vpc2/vpc/nodes/attach" {
+				attached = append(attached, req.Nodes...)
+			} else {
+				detached = append(detached, req.Nodes...)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "/v2/vpc2/vpc/nodes":
+			fmt.Fprint(w, `{"nodes":[{"id":"instance-2"},{"id":"instance-3"}],"meta":{"links":{"next":""}}}`)
+		case "/v2/vpc2/vpc":
+			fmt.Fprint(w, `{"vpc":{"id":"vpc","region":"ewr","ip_block":"10.99.0.0","prefix_length":24}}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	r := resourceVultrVPC2()
+	current := r.TestResourceData()
+	current.SetId("vpc")
+	current.Set("region", "ewr")
+	current.Set("ip_type", "v4")
+	current.Set("ip_block", "10.99.0.0")
+	current.Set("prefix_length", 24)
+	current.Set("node_ids", []string{"instance-1", "instance-2"})
+	state := current.State()
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"region":   "ewr",
+		"node_ids": []interface{}{"instance-2", "instance-3"},
+	})
+
+	diff, err := r.Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	newState, diags := r.Apply(context.Background(), state, diff, client)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(attached) != 1 || attached[0] != "instance-3" {
+		t.Errorf("expected instance-3 to be attached, got %v", attached)
+	}
+	if len(detached) != 1 || detached[0] != "instance-1" {
+		t.Errorf("expected instance-1 to be detached, got %v", detached)
+	}
+	if newState.Attributes["node_ids.#"] != "2" {
+		t.Errorf("expected 2 nodes in state, got %q", newState.Attributes["node_ids.#"])
+	}
+}
+
+func TestResourceVultrVPC2ReadImported(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/vpc2/vpc/nodes":
+			fmt.Fprint(w, `{"nodes":[],"meta":{"links":{"next":""}}}`)
+		case "/v2/vpc2/vpc":
+			fmt.Fprint(w, `{"vpc":{"id":"vpc","region":"ewr","ip_block":"10.99.0.0","prefix_length":24}}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	d := resourceVultrVPC2().TestResourceData()
+	d.SetId("vpc")
+
+	if diags := resourceVultrVPC2Read(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if d.Get("ip_type").(string) != "v4" {
+		t.Errorf("expected ip_type v4 after import, got %q", d.Get("ip_type"))
+	}
+}
+
+func testAccCheckVultrVPC2Destroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "vultr_vpc2" {
+			continue
+		}
+
+		client := testAccProvider.Meta().(*Client).govultrClient()
+		if _, err := getVPC2(context.Background(), client, rs.Primary.ID); err == nil {
+			return fmt.Errorf("vpc2 still exists: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccVultrVPC2Config(description string) string {
+	return fmt.Sprintf(`
+		resource "vultr_instance" "foo" {
+			plan   = "vc2-1c-1gb"
+			region = "ewr"
+			os_id  = 1743
+		}
+
+		resource "vultr_vpc2" "foo" {
+			region        = "ewr"
+			description   = "%s"
+			ip_block      = "10.99.0.0"
+			prefix_length = 24
+			node_ids      = [vultr_instance.foo.id]
+		}`, description)
+}
@@ -25,6 +25,7 @@ func TestAccVultrVPC(t *testing.T) {
 					resource.TestCheckResourceAttr("vultr_vpc.foo", "description", rDesc),
 					resource.TestCheckResourceAttrSet("vultr_vpc.foo", "date_created"),
 					resource.TestCheckResourceAttrSet("vultr_vpc.foo", "v4_subnet"),
+					resource.TestCheckResourceAttrSet("vultr_vpc.foo", "cidr"),
 				),
 			},
 		},
@@ -1,6 +1,11 @@
 package vultr
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vultr/govultr/v2"
+)
 
 // Lookup changes on a TF field and convert schema.Set to []string
 func tfChangeToSlices(fieldname string, d *schema.ResourceData) ([]string, []string) {
@@ -36,3 +41,35 @@ func diffSlice(x, y []string) []string {
 
 	return diff
 }
+
+// createdAfter reports whether date_created a is later than b. Dates that
+// can't be parsed are treated as the oldest.
+func createdAfter(a, b string) bool {
+	timeA, errA := time.Parse(time.RFC3339, a)
+	timeB, errB := time.Parse(time.RFC3339, b)
+	if errA != nil || errB != nil {
+		return errA == nil
+	}
+	return timeA.After(timeB)
+}
+
+// listAllPerPage is the largest page the API returns, which keeps the number
+// of requests counted against the rate limit down.
+const listAllPerPage = 500
+
+// listAll calls list for every page, following the cursor until the API
+// reports there is no next page. list appends each page to its own results.
+func listAll(list func(options *govultr.ListOptions) (*govultr.Meta, error)) error {
+	options := &govultr.ListOptions{PerPage: listAllPerPage}
+	for {
+		meta, err := list(options)
+		if err != nil {
+			return err
+		}
+
+		if meta == nil || meta.Links == nil || meta.Links.Next == "" {
+			return nil
+		}
+		options.Cursor = meta.Links.Next
+	}
+}
@@ -0,0 +1,40 @@
+package vultr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vultr/govultr/v2"
+)
+
+func TestListAll(t *testing.T) {
+	pages := map[string]*govultr.Meta{
+		"":      {Links: &govultr.Links{Next: "page2"}},
+		"page2": {Links: &govultr.Links{Next: "page3"}},
+		"page3": nil,
+	}
+
+	var cursors []string
+	err := listAll(func(options *govultr.ListOptions) (*govultr.Meta, error) {
+		if options.PerPage != listAllPerPage {
+			t.Errorf("expected %d per page, got %d", listAllPerPage, options.PerPage)
+		}
+		cursors = append(cursors, options.Cursor)
+		return pages[options.Cursor], nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cursors) != 3 || cursors[1] != "page2" || cursors[2] != "page3" {
+		t.Fatalf("unexpected cursors %q", cursors)
+	}
+
+	calls := 0
+	err = listAll(func(options *govultr.ListOptions) (*govultr.Meta, error) {
+		calls++
+		return pages[""], errors.New("rate limited")
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("expected to stop on the first error, got %v after %d calls", err, calls)
+	}
+}
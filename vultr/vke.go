@@ -1,10 +1,48 @@
 package vultr
 
 import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vultr/govultr/v2"
+	"gopkg.in/yaml.v3"
 )
 
+// kubeConfig holds the parts of a VKE kubeconfig needed to authenticate
+// against the cluster
+type kubeConfig struct {
+	Clusters []struct {
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		User struct {
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKeyData         string `yaml:"client-key-data"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// kubeConfigCredentials contains the decoded PEM certificates and host from a kubeconfig
+type kubeConfigCredentials struct {
+	Host                 string
+	ClientCertificate    string
+	ClientKey            string
+	ClusterCACertificate string
+}
+
 func nodePoolSchema(isNodePool bool) map[string]*schema.Schema {
 	s := map[string]*schema.Schema{
 		"label": {
@@ -26,14 +64,50 @@ func nodePoolSchema(isNodePool bool) map[string]*schema.Schema {
 			Default:  false,
 		},
 		"min_nodes": {
-			Type:     schema.TypeInt,
-			Optional: true,
-			Default:  1,
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Default:          1,
+			DiffSuppressFunc: suppressAutoScalerBounds,
 		},
 		"max_nodes": {
-			Type:     schema.TypeInt,
+			Type:             schema.TypeInt,
+			Optional:         true,
+			Default:          1,
+			DiffSuppressFunc: suppressAutoScalerBounds,
+		},
+		"labels": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"script_id": {
+			Type:     schema.TypeString,
 			Optional: true,
-			Default:  1,
+		},
+		"user_data": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"taints": {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"key": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"value": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"effect": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice([]string{"NoSchedule", "PreferNoSchedule", "NoExecute"}, false),
+					},
+				},
+			},
 		},
 		//computed fields
 		"id": {
@@ -52,6 +126,10 @@ func nodePoolSchema(isNodePool bool) map[string]*schema.Schema {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
+		"ready": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
 		"nodes": {
 			Type:     schema.TypeList,
 			Computed: true,
@@ -73,12 +151,22 @@ func nodePoolSchema(isNodePool bool) map[string]*schema.Schema {
 						Type:     schema.TypeString,
 						Computed: true,
 					},
+					"main_ip": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"instance_id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
 				},
 			},
 		},
 	}
 
 	if isNodePool {
+		// The API cannot change the plan of an existing pool
+		s["plan"].ForceNew = true
 		s["cluster_id"] = &schema.Schema{
 			Type:         schema.TypeString,
 			Required:     true,
@@ -91,13 +179,380 @@ func nodePoolSchema(isNodePool bool) map[string]*schema.Schema {
 		}
 
 	} else {
-		// Make tags unmodifiable for the vultr_kubernetes resource
-		// This lets us know which node pool was part of the vultr_kubernetes resource
+		// The default node pool is tracked by its ID, so the tag is free for users
 		s["tag"] = &schema.Schema{
 			Type:     schema.TypeString,
+			Optional: true,
 			Computed: true,
 		}
 	}
 
 	return s
 }
+
+// nodeMainIPs looks up the main IP of the instance backing each node, keyed
+// by node ID. Instances are listed rather than fetched one per node, filtered
+// by the node pool tag when the pool has one. Nodes that are still
+// provisioning or can't be found are left out.
+func nodeMainIPs(ctx context.Context, client *govultr.Client, tag string, nodes []govultr.Node) map[string]string {
+	ips := make(map[string]string, len(nodes))
+	if len(nodes) == 0 {
+		return ips
+	}
+
+	ids := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		ids[n.ID] = true
+	}
+
+	found := listNodeMainIPs(ctx, client, tag, ids, ips)
+
+	// Instances that predate the pool tag may not carry it yet
+	if found == 0 && tag != "" {
+		listNodeMainIPs(ctx, client, "", ids, ips)
+	}
+
+	return ips
+}
+
+// listNodeMainIPs adds the main IPs of the listed instances in ids to ips and
+// returns how many of them were listed.
+func listNodeMainIPs(ctx context.Context, client *govultr.Client, tag string, ids map[string]bool, ips map[string]string) int {
+	found := 0
+	options := &govultr.ListOptions{PerPage: 500, Tag: tag}
+	for {
+		instances, meta, err := client.Instance.List(ctx, options)
+		if err != nil {
+			log.Printf("[WARN] could not list instances for nodes: %v", err)
+			return found
+		}
+
+		for _, instance := range instances {
+			if !ids[instance.ID] {
+				continue
+			}
+
+			found++
+			if instance.MainIP != "" && instance.MainIP != "0.0.0.0" {
+				ips[instance.ID] = instance.MainIP
+			}
+		}
+
+		if found == len(ids) || meta == nil || meta.Links == nil || meta.Links.Next == "" {
+			return found
+		}
+		options.Cursor = meta.Links.Next
+	}
+}
+
+// isNodePoolReady reports whether every node of the pool is active and the
+// pool has the node count it should, which is within the bounds while the
+// auto scaler is enabled.
+func isNodePoolReady(np *govultr.NodePool) bool {
+	for _, n := range np.Nodes {
+		if n.Status != "active" {
+			return false
+		}
+	}
+
+	count := len(np.Nodes)
+	if np.AutoScaler {
+		return count >= np.MinNodes && count <= np.MaxNodes
+	}
+	return count == np.NodeQuantity
+}
+
+// validateNodePoolAutoScaler checks the auto scaler bounds of a node pool. A
+// node_quantity outside of the bounds is only logged since the auto scaler
+// will bring it back in range.
+func validateNodePoolAutoScaler(autoScaler bool, minNodes, maxNodes, quantity int) error {
+	if !autoScaler {
+		return nil
+	}
+
+	if minNodes < 1 {
+		return fmt.Errorf("min_nodes must be at least 1 when auto_scaler is enabled, got %d", minNodes)
+	}
+
+	if maxNodes < minNodes {
+		return fmt.Errorf("max_nodes (%d) cannot be less than min_nodes (%d)", maxNodes, minNodes)
+	}
+
+	if quantity < minNodes || quantity > maxNodes {
+		log.Printf("[WARN] node_quantity %d is outside of the auto scaler range [%d, %d]", quantity, minNodes, maxNodes)
+	}
+
+	return nil
+}
+
+// validateNodePoolAutoScalerEnable requires explicit bounds when an update
+// turns the auto scaler on. Otherwise the schema defaults of 1 would silently
+// pin the pool to a single node.
+func validateNodePoolAutoScalerEnable(minNodesSet, maxNodesSet bool) error {
+	if !minNodesSet || !maxNodesSet {
+		return fmt.Errorf("min_nodes and max_nodes must be set when enabling auto_scaler on an existing node pool")
+	}
+
+	return nil
+}
+
+// vkeNodePoolReqUpdate is a node pool update. Unlike
+// govultr.NodePoolReqUpdate it always sends the auto scaler bounds, labels
+// and taints, so they can be cleared.
+type vkeNodePoolReqUpdate struct {
+	NodeQuantity int                `json:"node_quantity,omitempty"`
+	Tag          *string            `json:"tag,omitempty"`
+	MinNodes     int                `json:"min_nodes"`
+	MaxNodes     int                `json:"max_nodes"`
+	AutoScaler   *bool              `json:"auto_scaler,omitempty"`
+	Labels       map[string]string  `json:"labels"`
+	Taints       []vkeNodePoolTaint `json:"taints"`
+}
+
+// nodePoolUpdateReq builds the node pool update for the desired final state.
+// Disabling the auto scaler clears its bounds and leaves the pool pinned at
+// node_quantity.
+func nodePoolUpdateReq(quantity int, tag string, autoScaler bool, minNodes, maxNodes int, labels map[string]interface{}, taints *schema.Set) *vkeNodePoolReqUpdate {
+	req := &vkeNodePoolReqUpdate{
+		NodeQuantity: quantity,
+		Tag:          govultr.StringToStringPtr(tag),
+		AutoScaler:   govultr.BoolToBoolPtr(autoScaler),
+		Labels:       expandNodePoolLabels(labels),
+		Taints:       expandNodePoolTaints(taints),
+	}
+
+	if autoScaler {
+		req.MinNodes = minNodes
+		req.MaxNodes = maxNodes
+	}
+
+	return req
+}
+
+// updateVKENodePool sends a node pool update directly, since
+// govultr.NodePoolReqUpdate can't clear the auto scaler bounds and has no
+// fields for labels and taints.
+func updateVKENodePool(ctx context.Context, client *govultr.Client, clusterID, nodePoolID string, updateReq *vkeNodePoolReqUpdate) (*vkeNodePool, error) {
+	req, err := client.NewRequest(ctx, http.MethodPatch, fmt.Sprintf("/v2/kubernetes/clusters/%s/node-pools/%s", clusterID, nodePoolID), updateReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return doVKENodePoolRequest(ctx, client, req)
+}
+
+// suppressAutoScalerBounds ignores changes to min_nodes and max_nodes while
+// the auto scaler is disabled. The bounds are cleared then, so the API returns
+// 0 for them instead of the schema defaults.
+func suppressAutoScalerBounds(k, old, new string, d *schema.ResourceData) bool {
+	autoScaler := k[:strings.LastIndex(k, ".")+1] + "auto_scaler"
+	return !d.Get(autoScaler).(bool)
+}
+
+// vkeNodePoolTaint is a Kubernetes taint applied to every node of a pool.
+type vkeNodePoolTaint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Effect string `json:"effect"`
+}
+
+// vkeNodePool is a node pool including the labels and taints that
+// govultr.NodePool does not decode.
+type vkeNodePool struct {
+	govultr.NodePool
+	Labels map[string]string  `json:"labels"`
+	Taints []vkeNodePoolTaint `json:"taints"`
+}
+
+// vkeNodePoolReq is a node pool create request including the labels and
+// taints that govultr.NodePoolReq has no fields for.
+type vkeNodePoolReq struct {
+	govultr.NodePoolReq
+	Labels map[string]string  `json:"labels,omitempty"`
+	Taints []vkeNodePoolTaint `json:"taints,omitempty"`
+}
+
+// createVKENodePool creates a node pool directly so that its labels and
+// taints are sent, see vkeNodePoolReq.
+func createVKENodePool(ctx context.Context, client *govultr.Client, clusterID string, createReq *vkeNodePoolReq) (*vkeNodePool, error) {
+	req, err := client.NewRequest(ctx, http.MethodPost, fmt.Sprintf("/v2/kubernetes/clusters/%s/node-pools", clusterID), createReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return doVKENodePoolRequest(ctx, client, req)
+}
+
+// getVKENodePool fetches a node pool directly so that its labels and taints
+// are decoded, see vkeNodePool.
+func getVKENodePool(ctx context.Context, client *govultr.Client, clusterID, nodePoolID string) (*vkeNodePool, error) {
+	req, err := client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("/v2/kubernetes/clusters/%s/node-pools/%s", clusterID, nodePoolID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return doVKENodePoolRequest(ctx, client, req)
+}
+
+func doVKENodePoolRequest(ctx context.Context, client *govultr.Client, req *http.Request) (*vkeNodePool, error) {
+	var nodePool struct {
+		NodePool *vkeNodePool `json:"node_pool"`
+	}
+	if err := client.DoWithContext(ctx, req, &nodePool); err != nil {
+		return nil, err
+	}
+
+	if nodePool.NodePool == nil {
+		return nil, fmt.Errorf("node pool was not returned by the API")
+	}
+
+	return nodePool.NodePool, nil
+}
+
+// expandNodePoolLabels converts the labels of a node pool for the API.
+func expandNodePoolLabels(labels map[string]interface{}) map[string]string {
+	expanded := make(map[string]string, len(labels))
+	for k, v := range labels {
+		expanded[k] = v.(string)
+	}
+	return expanded
+}
+
+// expandNodePoolTaints converts the taints of a node pool for the API.
+func expandNodePoolTaints(taints *schema.Set) []vkeNodePoolTaint {
+	expanded := []vkeNodePoolTaint{}
+	for _, t := range taints.List() {
+		taint := t.(map[string]interface{})
+		expanded = append(expanded, vkeNodePoolTaint{
+			Key:    taint["key"].(string),
+			Value:  taint["value"].(string),
+			Effect: taint["effect"].(string),
+		})
+	}
+	return expanded
+}
+
+func flattenNodePoolTaints(taints []vkeNodePoolTaint) []map[string]interface{} {
+	var flattened []map[string]interface{}
+	for _, t := range taints {
+		flattened = append(flattened, map[string]interface{}{
+			"key":    t.Key,
+			"value":  t.Value,
+			"effect": t.Effect,
+		})
+	}
+	return flattened
+}
+
+// checkNodePoolBootstrap rejects node pool startup scripts and user data. The
+// VKE API has no way to run them on nodes and govultr.NodePoolReq has no
+// fields for them, so they would otherwise be silently dropped.
+func checkNodePoolBootstrap(scriptID, userData string) error {
+	if scriptID != "" || userData != "" {
+		return fmt.Errorf("node pool script_id and user_data are not supported by the VKE API")
+	}
+
+	return nil
+}
+
+// parseKubeConfig decodes the base64 encoded kubeconfig returned by the API
+// and extracts the credentials of the first cluster and user
+func parseKubeConfig(encoded string) (*kubeConfigCredentials, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding kubeconfig: %v", err)
+	}
+
+	var config kubeConfig
+	if err := yaml.Unmarshal(raw, &config); err != nil {
+		return nil, fmt.Errorf("error parsing kubeconfig: %v", err)
+	}
+
+	if len(config.Clusters) == 0 || len(config.Users) == 0 {
+		return nil, fmt.Errorf("kubeconfig does not contain any clusters or users")
+	}
+
+	cluster := config.Clusters[0].Cluster
+	user := config.Users[0].User
+
+	ca, err := base64.StdEncoding.DecodeString(cluster.CertificateAuthorityData)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding cluster ca certificate: %v", err)
+	}
+
+	cert, err := base64.StdEncoding.DecodeString(user.ClientCertificateData)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding client certificate: %v", err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(user.ClientKeyData)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding client key: %v", err)
+	}
+
+	return &kubeConfigCredentials{
+		Host:                 cluster.Server,
+		ClientCertificate:    string(cert),
+		ClientKey:            string(key),
+		ClusterCACertificate: string(ca),
+	}, nil
+}
+
+// writeKubeConfig decodes the base64 encoded kubeconfig and writes it to path
+// with 0600 permissions. The file is replaced atomically and only when its
+// content changes, so refreshes after a credential rotation update it.
+func writeKubeConfig(path, encoded string) error {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("error decoding kubeconfig: %v", err)
+	}
+
+	path, err = expandPath(path)
+	if err != nil {
+		return err
+	}
+
+	if current, err := ioutil.ReadFile(path); err == nil && bytes.Equal(current, raw) {
+		return nil
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("error creating directory for kube_config_path: %v", err)
+	}
+
+	// TempFile creates the file with 0600 permissions
+	tmp, err := ioutil.TempFile(dir, ".kubeconfig-*")
+	if err != nil {
+		return fmt.Errorf("error writing kube_config_path: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("error writing kube_config_path: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("error writing kube_config_path: %v", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("error writing kube_config_path: %v", err)
+	}
+
+	return nil
+}
+
+// expandPath expands environment variables and a leading ~ in path.
+func expandPath(path string) (string, error) {
+	path = os.ExpandEnv(path)
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error expanding %s: %v", path, err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
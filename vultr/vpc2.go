@@ -0,0 +1,133 @@
+package vultr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/vultr/govultr/v2"
+)
+
+// govultr has no VPC 2.0 service, so the VPC 2.0 endpoints are called
+// directly through the govultr client.
+const vpc2Path = "/v2/vpc2"
+
+type vpc2 struct {
+	ID           string `json:"id"`
+	Region       string `json:"region"`
+	Description  string `json:"description"`
+	IPBlock      string `json:"ip_block"`
+	PrefixLength int    `json:"prefix_length"`
+	DateCreated  string `json:"date_created"`
+}
+
+type vpc2Node struct {
+	ID          string `json:"id"`
+	IPAddress   string `json:"ip_address"`
+	Description string `json:"description"`
+	NodeStatus  string `json:"node_status"`
+}
+
+type vpc2Req struct {
+	Region       string `json:"region,omitempty"`
+	Description  string `json:"description"`
+	IPType       string `json:"ip_type,omitempty"`
+	IPBlock      string `json:"ip_block,omitempty"`
+	PrefixLength int    `json:"prefix_length,omitempty"`
+}
+
+type vpc2Base struct {
+	VPC *vpc2 `json:"vpc"`
+}
+
+type vpc2NodesReq struct {
+	Nodes []string `json:"nodes"`
+}
+
+func createVPC2(ctx context.Context, client *govultr.Client, vpcReq *vpc2Req) (*vpc2, error) {
+	req, err := client.NewRequest(ctx, http.MethodPost, vpc2Path, vpcReq)
+	if err != nil {
+		return nil, err
+	}
+
+	vpc := new(vpc2Base)
+	if err := client.DoWithContext(ctx, req, vpc); err != nil {
+		return nil, err
+	}
+
+	return vpc.VPC, nil
+}
+
+func getVPC2(ctx context.Context, client *govultr.Client, vpcID string) (*vpc2, error) {
+	req, err := client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s", vpc2Path, vpcID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	vpc := new(vpc2Base)
+	if err := client.DoWithContext(ctx, req, vpc); err != nil {
+		return nil, err
+	}
+
+	return vpc.VPC, nil
+}
+
+func updateVPC2(ctx context.Context, client *govultr.Client, vpcID, description string) error {
+	req, err := client.NewRequest(ctx, http.MethodPut, fmt.Sprintf("%s/%s", vpc2Path, vpcID), &vpc2Req{Description: description})
+	if err != nil {
+		return err
+	}
+
+	return client.DoWithContext(ctx, req, nil)
+}
+
+func deleteVPC2(ctx context.Context, client *govultr.Client, vpcID string) error {
+	req, err := client.NewRequest(ctx, http.MethodDelete, fmt.Sprintf("%s/%s", vpc2Path, vpcID), nil)
+	if err != nil {
+		return err
+	}
+
+	return client.DoWithContext(ctx, req, nil)
+}
+
+func listVPC2Nodes(ctx context.Context, client *govultr.Client, vpcID string) ([]vpc2Node, error) {
+	var nodes []vpc2Node
+	cursor := ""
+	for {
+		req, err := client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s/%s/nodes", vpc2Path, vpcID), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if cursor != "" {
+			q := req.URL.Query()
+			q.Set("cursor", cursor)
+			req.URL.RawQuery = q.Encode()
+		}
+
+		page := new(struct {
+			Nodes []vpc2Node    `json:"nodes"`
+			Meta  *govultr.Meta `json:"meta"`
+		})
+		if err := client.DoWithContext(ctx, req, page); err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, page.Nodes...)
+
+		if page.Meta == nil || page.Meta.Links == nil || page.Meta.Links.Next == "" {
+			return nodes, nil
+		}
+		cursor = page.Meta.Links.Next
+	}
+}
+
+// updateVPC2Nodes attaches or detaches instances, depending on action.
+func updateVPC2Nodes(ctx context.Context, client *govultr.Client, vpcID, action string, nodeIDs []string) error {
+	req, err := client.NewRequest(ctx, http.MethodPost, fmt.Sprintf("%s/%s/nodes/%s", vpc2Path, vpcID, action), &vpc2NodesReq{Nodes: nodeIDs})
+	if err != nil {
+		return err
+	}
+
+	return client.DoWithContext(ctx, req, nil)
+}